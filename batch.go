@@ -0,0 +1,109 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Delegation is a single entry of the DelegateBatch.
+type Delegation struct {
+	To     common.Address
+	Amount *big.Int
+}
+
+// DelegationResult is an outcome of the single delegation in the batch. Tx is nil if delegation
+// failed validation or wasn't broadcasted.
+type DelegationResult struct {
+	Delegation
+	Tx     *PendingTx
+	Result TxResult
+	Err    error
+}
+
+// DelegateBatch delegates to every transcoder from the batch. All entries are validated against min delegation
+// and the sender balance must cover amounts and fees of all valid entries. Valid entries are broadcasted in order
+// and then awaited concurrently. Nonces are consecutive unless other transactions are sent from the same account
+// while batch is broadcasted. Failure of one entry doesn't abort the rest, results are returned in the order
+// of delegations. Error is returned only if batch can't be started. WithNonce is not supported.
+func (c *Client) DelegateBatch(ctx context.Context, signer Signer, delegations []Delegation, opts ...TxOption) ([]DelegationResult, error) {
+	cfg := c.newTxConfig(opts)
+	if cfg.nonce != nil {
+		return nil, errors.New("nonce can't be set for a batch of delegations")
+	}
+	min, err := c.GetMinDelegation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]DelegationResult, len(delegations))
+	for i, delegation := range delegations {
+		results[i].Delegation = delegation
+		if delegation.Amount == nil {
+			results[i].Err = fmt.Errorf("delegation to 0x%x doesn't have amount", delegation.To)
+		} else if delegation.Amount.Cmp(min) < 0 {
+			results[i].Err = &InsufficientStakeError{Transcoder: delegation.To, Amount: delegation.Amount, Required: min}
+		}
+	}
+	if err := c.checkBatchFunds(ctx, signer.Address(), results, cfg); err != nil {
+		return nil, err
+	}
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		to, amount := results[i].To, results[i].Amount
+		tx, err := c.submit(ctx, signer, DelegateCall(to, amount), cfg)
+		if err != nil {
+			// nonce is not consumed by failed submission, following entries remain consecutive.
+			results[i].Err = err
+			continue
+		}
+		results[i].Tx = c.newPendingTx(TxDelegate, signer.Address(), signer, tx, cfg,
+			fmt.Sprintf("failed to delegate from 0x%x to 0x%x. amount %v", signer.Address(), to, amount),
+		)
+	}
+	var wg sync.WaitGroup
+	for i := range results {
+		if results[i].Tx == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(result *DelegationResult) {
+			defer wg.Done()
+			result.Result, result.Err = result.Tx.Wait(ctx)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// checkBatchFunds ensures that sender balance covers amounts and fees of all valid delegations.
+// Entries that fail gas estimation are marked as failed. Returns *InsufficientBalanceError otherwise.
+func (c *Client) checkBatchFunds(ctx context.Context, from common.Address, results []DelegationResult, cfg txConfig) error {
+	balance, err := c.client.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return err
+	}
+	required := new(big.Int)
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		call := DelegateCall(results[i].To, results[i].Amount)
+		gas, gasPrice, err := c.fees(ctx, from, call, cfg)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		required = required.Add(required, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas)))
+		required = required.Add(required, call.Value)
+	}
+	if balance.Cmp(required) < 0 {
+		return &InsufficientBalanceError{Account: from, Balance: balance, Required: required}
+	}
+	return nil
+}
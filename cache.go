@@ -0,0 +1,240 @@
+package staking
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CacheConfig configures CachedClient.
+type CacheConfig struct {
+	// HeadInterval is how long the head block is reused before it is requested again. Zero requests head
+	// on every read.
+	HeadInterval time.Duration
+	// ParamsTTL is how long unbonding period, min delegation and required self stake are cached regardless
+	// of new blocks. Zero caches them per block, like any other read.
+	ParamsTTL time.Duration
+}
+
+// CachedClient is a read-through cache around Client. Results of reads are cached by block number, reads
+// without AtBlock are pinned to the head block and cache is invalidated when new head is seen. Results at
+// blocks older than the head are not cached. Transactions and not cached reads are served by the Client.
+// Returned values are shared between callers and must not be modified.
+type CachedClient struct {
+	*Client
+	cfg CacheConfig
+
+	mu      sync.Mutex
+	head    *big.Int
+	checked time.Time
+	entries map[cacheKey]interface{}
+	params  map[string]paramEntry
+}
+
+type cacheKey struct {
+	block   uint64
+	method  string
+	address common.Address
+}
+
+type paramEntry struct {
+	value   *big.Int
+	expires time.Time
+}
+
+// NewCachedClient returns client that caches reads of the client.
+func NewCachedClient(client *Client, cfg CacheConfig) *CachedClient {
+	return &CachedClient{
+		Client:  client,
+		cfg:     cfg,
+		entries: map[cacheKey]interface{}{},
+		params:  map[string]paramEntry{},
+	}
+}
+
+// block returns block of the read and options pinned to it.
+func (c *CachedClient) block(ctx context.Context, opts []ReadOption) (uint64, []ReadOption, error) {
+	if number := c.callOpts(ctx, opts).BlockNumber; number != nil {
+		return number.Uint64(), opts, nil
+	}
+	c.mu.Lock()
+	head := c.head
+	if head == nil || time.Since(c.checked) >= c.cfg.HeadInterval {
+		head = nil
+	}
+	c.mu.Unlock()
+	if head == nil {
+		header, err := c.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return 0, nil, err
+		}
+		head = header.Number
+		c.setHead(head)
+	}
+	return head.Uint64(), append(opts[:len(opts):len(opts)], AtBlock(head)), nil
+}
+
+// setHead evicts entries older than the new head.
+func (c *CachedClient) setHead(head *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checked = time.Now()
+	if c.head != nil && c.head.Cmp(head) >= 0 {
+		return
+	}
+	c.head = head
+	for key := range c.entries {
+		if key.block < head.Uint64() {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cached returns cached result of the read at the block or fetches and caches it.
+func (c *CachedClient) cached(key cacheKey, fetch func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	value, exist := c.entries[key]
+	c.mu.Unlock()
+	if exist {
+		return value, nil
+	}
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.head == nil || key.block >= c.head.Uint64() {
+		c.entries[key] = value
+	}
+	c.mu.Unlock()
+	return value, nil
+}
+
+// param returns value of the contract parameter, cached for ParamsTTL unless read is pinned with AtBlock.
+func (c *CachedClient) param(ctx context.Context, method string, opts []ReadOption,
+	fetch func(context.Context, ...ReadOption) (*big.Int, error)) (*big.Int, error) {
+	if c.cfg.ParamsTTL == 0 || c.callOpts(ctx, opts).BlockNumber != nil {
+		block, opts, err := c.block(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		value, err := c.cached(cacheKey{block: block, method: method}, func() (interface{}, error) {
+			return fetch(ctx, opts...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return value.(*big.Int), nil
+	}
+	c.mu.Lock()
+	entry, exist := c.params[method]
+	c.mu.Unlock()
+	if exist && time.Now().Before(entry.expires) {
+		return entry.value, nil
+	}
+	value, err := fetch(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.params[method] = paramEntry{value: value, expires: time.Now().Add(c.cfg.ParamsTTL)}
+	c.mu.Unlock()
+	return value, nil
+}
+
+func (c *CachedClient) GetUnbondingPeriod(ctx context.Context, opts ...ReadOption) (*big.Int, error) {
+	return c.param(ctx, "unbondingPeriod", opts, c.Client.GetUnbondingPeriod)
+}
+
+func (c *CachedClient) GetMinDelegation(ctx context.Context, opts ...ReadOption) (*big.Int, error) {
+	return c.param(ctx, "minDelegation", opts, c.Client.GetMinDelegation)
+}
+
+func (c *CachedClient) GetRequiredSelfStake(ctx context.Context, opts ...ReadOption) (*big.Int, error) {
+	return c.param(ctx, "minSelfStake", opts, c.Client.GetRequiredSelfStake)
+}
+
+func (c *CachedClient) TranscodersCount(ctx context.Context, opts ...ReadOption) (*big.Int, error) {
+	block, opts, err := c.block(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	count, err := c.cached(cacheKey{block: block, method: "transcodersCount"}, func() (interface{}, error) {
+		return c.Client.TranscodersCount(ctx, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return count.(*big.Int), nil
+}
+
+func (c *CachedClient) GetTranscoderState(ctx context.Context, address common.Address, opts ...ReadOption) (State, error) {
+	block, opts, err := c.block(ctx, opts)
+	if err != nil {
+		return 0, err
+	}
+	state, err := c.cached(cacheKey{block: block, method: "getTranscoderState", address: address}, func() (interface{}, error) {
+		return c.Client.GetTranscoderState(ctx, address, opts...)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return state.(State), nil
+}
+
+func (c *CachedClient) GetTranscoder(ctx context.Context, address common.Address, opts ...ReadOption) (Transcoder, error) {
+	block, opts, err := c.block(ctx, opts)
+	if err != nil {
+		return Transcoder{}, err
+	}
+	tcr, err := c.cached(cacheKey{block: block, method: "transcoder", address: address}, func() (interface{}, error) {
+		return c.Client.GetTranscoder(ctx, address, opts...)
+	})
+	if err != nil {
+		return Transcoder{}, err
+	}
+	return tcr.(Transcoder), nil
+}
+
+// GetAllTranscoders returns all transcoders at the same block, head block unless set with AtBlock.
+func (c *CachedClient) GetAllTranscoders(ctx context.Context, opts ...ReadOption) ([]Transcoder, error) {
+	block, opts, err := c.block(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	tcrs, err := c.cached(cacheKey{block: block, method: "allTranscoders"}, func() (interface{}, error) {
+		return c.Client.GetAllTranscoders(ctx, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tcrs.([]Transcoder), nil
+}
+
+// GetBondedTranscoders returns bonded transcoders at the same block, head block unless set with AtBlock.
+func (c *CachedClient) GetBondedTranscoders(ctx context.Context, opts ...ReadOption) ([]Transcoder, error) {
+	block, opts, err := c.block(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	tcrs, err := c.cached(cacheKey{block: block, method: "bondedTranscoders"}, func() (interface{}, error) {
+		all, err := c.GetAllTranscoders(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		var bonded []Transcoder
+		for _, tcr := range all {
+			if tcr.State == StateBonded {
+				bonded = append(bonded, tcr)
+			}
+		}
+		return bonded, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tcrs.([]Transcoder), nil
+}
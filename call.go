@@ -0,0 +1,184 @@
+package staking
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Call is a state changing invocation of the staking contract.
+type Call struct {
+	Kind  TxKind
+	Value *big.Int
+
+	method string
+	args   []interface{}
+}
+
+// DelegateCall delegates amount to the transcoder.
+func DelegateCall(to common.Address, amount *big.Int) Call {
+	return Call{Kind: TxDelegate, Value: amount, method: "delegate", args: []interface{}{to}}
+}
+
+// RegisterTranscoderCall registers sender as a transcoder.
+func RegisterTranscoderCall(rewardRate uint64) Call {
+	return Call{Kind: TxRegisterTranscoder, method: "registerTranscoder", args: []interface{}{new(big.Int).SetUint64(rewardRate)}}
+}
+
+// RequestWithdrawalCall requests unbonding of the amount delegated to the transcoder.
+func RequestWithdrawalCall(from common.Address, amount *big.Int) Call {
+	return Call{Kind: TxRequestWithdrawal, method: "requestUnbonding", args: []interface{}{from, amount}}
+}
+
+// CompleteWithdrawalsCall completes all pending withdrawals of the sender.
+func CompleteWithdrawalsCall() Call {
+	return Call{Kind: TxCompleteWithdrawals, method: "withdrawAllPending"}
+}
+
+// kindMethods maps kind of the call to the staking contract method.
+var kindMethods = map[TxKind]string{
+	TxDelegate:            "delegate",
+	TxRegisterTranscoder:  "registerTranscoder",
+	TxRequestWithdrawal:   "requestUnbonding",
+	TxCompleteWithdrawals: "withdrawAllPending",
+}
+
+// target returns transcoder of the delegation or withdrawal request, zero address for other calls.
+func (c Call) target() common.Address {
+	if c.Kind != TxDelegate && c.Kind != TxRequestWithdrawal || len(c.args) == 0 {
+		return common.Address{}
+	}
+	target, _ := c.args[0].(common.Address)
+	return target
+}
+
+// revertSelector is a selector of solidity Error(string).
+var revertSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+// unpackRevert decodes abi encoded Error(string). Returns false if data is not a revert.
+func unpackRevert(data []byte) (string, bool) {
+	if len(data) < 4 || !bytes.Equal(data[:4], revertSelector) {
+		return "", false
+	}
+	data = data[4:]
+	if len(data) < 64 {
+		return "", true
+	}
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(data)) {
+		return "", true
+	}
+	start := offset.Uint64()
+	length := new(big.Int).SetBytes(data[start : start+32])
+	if !length.IsUint64() || length.Uint64() > uint64(len(data))-start-32 {
+		return "", true
+	}
+	return string(data[start+32 : start+32+length.Uint64()]), true
+}
+
+// revertFromError extracts revert reason from the error returned by the node. Nodes report reverts of eth_call
+// and eth_estimateGas as "execution reverted: <reason>", older go-ethereum nodes fail estimation with
+// "always failing transaction" without a reason. Other errors, e.g. transport failures, are not reverts.
+func revertFromError(err error) (string, bool) {
+	msg := err.Error()
+	if i := strings.Index(msg, "execution reverted"); i >= 0 {
+		reason := strings.TrimPrefix(msg[i+len("execution reverted"):], ":")
+		return strings.TrimSpace(reason), true
+	}
+	if strings.Contains(msg, "always failing transaction") {
+		return "", true
+	}
+	return "", false
+}
+
+// revertError returns *RevertError for the reverted call. Reason strings of the contract are not relied upon,
+// revert is explained by the same state checks that are performed before broadcasting the call.
+func (c *Client) revertError(ctx context.Context, from common.Address, call Call, reason string) *RevertError {
+	err := newRevertError(from, call, reason)
+	var known error
+	switch call.Kind {
+	case TxDelegate:
+		if call.Value != nil {
+			known = c.checkDelegateTarget(ctx, call.target(), call.Value)
+		}
+	case TxRegisterTranscoder:
+		known = c.checkRegisterTranscoder(ctx, from)
+	case TxRequestWithdrawal:
+		if amount, ok := call.args[1].(*big.Int); ok && amount != nil {
+			known = c.checkRequestWithdrawal(ctx, from, call.target(), amount)
+		}
+	case TxCompleteWithdrawals:
+		known = c.checkCompleteWithdrawals(ctx, from)
+	}
+	// failed checks, e.g. due to transport errors, don't explain the revert.
+	for _, typed := range []error{ErrInsufficientStake, ErrTranscoderNotRegistered, ErrAlreadyRegistered,
+		ErrOverWithdrawal, ErrNoPendingWithdrawals} {
+		if errors.Is(known, typed) {
+			err.known = known
+			break
+		}
+	}
+	return err
+}
+
+func (c *Client) callMsg(from common.Address, call Call) (ethereum.CallMsg, error) {
+	data, err := c.abi.Pack(call.method, call.args...)
+	if err != nil {
+		return ethereum.CallMsg{}, err
+	}
+	return ethereum.CallMsg{
+		From:  from,
+		To:    &c.address,
+		Value: call.Value,
+		Data:  data,
+	}, nil
+}
+
+// estimateGas returns *GasEstimationError if node failed to estimate gas.
+func (c *Client) estimateGas(ctx context.Context, from common.Address, call Call) (uint64, error) {
+	msg, err := c.callMsg(from, call)
+	if err != nil {
+		return 0, err
+	}
+	gas, err := c.client.EstimateGas(ctx, msg)
+	if err != nil {
+		if reason, ok := revertFromError(err); ok {
+			err = c.revertError(ctx, from, call, reason)
+		}
+		return 0, &GasEstimationError{Kind: call.Kind, From: from, Err: err}
+	}
+	return gas, nil
+}
+
+// Simulate executes call on the latest state without broadcasting transaction. If call reverts *RevertError
+// with decoded reason is returned.
+func (c *Client) Simulate(ctx context.Context, from common.Address, call Call) error {
+	msg, err := c.callMsg(from, call)
+	if err != nil {
+		return err
+	}
+	out, err := c.client.CallContract(ctx, msg, nil)
+	if err != nil {
+		if reason, ok := revertFromError(err); ok {
+			return c.revertError(ctx, from, call, reason)
+		}
+		return err
+	}
+	if reason, ok := unpackRevert(out); ok {
+		return c.revertError(ctx, from, call, reason)
+	}
+	// call output doesn't indicate failure if contract reverts without reason.
+	// estimation fails for any transaction that reverts.
+	if _, err := c.client.EstimateGas(ctx, msg); err != nil {
+		if reason, ok := revertFromError(err); ok {
+			return c.revertError(ctx, from, call, reason)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func packRevert(t *testing.T, reason string) []byte {
+	typ, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: typ}}.Pack(reason)
+	require.NoError(t, err)
+	return append(append([]byte{}, revertSelector...), packed...)
+}
+
+func TestUnpackRevert(t *testing.T) {
+	reason, ok := unpackRevert(packRevert(t, "transcoder already registered"))
+	require.True(t, ok)
+	require.Equal(t, "transcoder already registered", reason)
+
+	_, ok = unpackRevert([]byte{1, 2, 3, 4})
+	require.False(t, ok)
+
+	reason, ok = unpackRevert(revertSelector)
+	require.True(t, ok)
+	require.Empty(t, reason)
+}
+
+func TestRevertErrorReason(t *testing.T) {
+	err := error(newRevertError(common.Address{1}, RegisterTranscoderCall(10), "transcoder already registered"))
+	require.True(t, errors.Is(err, ErrTransactionReverted))
+	// reason strings are not mapped to typed errors
+	require.False(t, errors.Is(err, ErrAlreadyRegistered))
+
+	var revert *RevertError
+	require.True(t, errors.As(err, &revert))
+	require.Equal(t, "transcoder already registered", revert.Reason)
+	require.Equal(t, common.Address{1}, revert.From)
+}
+
+func TestRevertFromError(t *testing.T) {
+	reason, ok := revertFromError(fmt.Errorf("call: %w", nodeError("execution reverted: transcoder already registered")))
+	require.True(t, ok)
+	require.Equal(t, "transcoder already registered", reason)
+
+	reason, ok = revertFromError(nodeError("execution reverted"))
+	require.True(t, ok)
+	require.Empty(t, reason)
+
+	_, ok = revertFromError(nodeError("gas required exceeds allowance (8000000) or always failing transaction"))
+	require.True(t, ok)
+
+	_, ok = revertFromError(nodeError("daily request count exceeded, request rate limited"))
+	require.False(t, ok)
+	_, ok = revertFromError(context.DeadlineExceeded)
+	require.False(t, ok)
+}
+
+// estimateBackend returns empty output for every call and fails estimation with err.
+type estimateBackend struct {
+	ETHBackend
+	err error
+}
+
+func (b estimateBackend) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func (b estimateBackend) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 0, b.err
+}
+
+func TestSimulateTransportError(t *testing.T) {
+	transport := errors.New("connection reset by peer")
+	client, err := NewClient(estimateBackend{err: transport}, common.Address{1})
+	require.NoError(t, err)
+	err = client.Simulate(context.Background(), common.Address{2}, CompleteWithdrawalsCall())
+	require.Equal(t, transport, err)
+	require.False(t, errors.Is(err, ErrTransactionReverted))
+}
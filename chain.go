@@ -0,0 +1,40 @@
+package staking
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// chainIDReader is implemented by backends that report chain id, e.g. ethclient.Client.
+type chainIDReader interface {
+	ChainID(context.Context) (*big.Int, error)
+}
+
+// ChainID returns chain id that is used to sign transactions with EIP-155 replay protection.
+// Chain id configured with WithChainID is verified against the node once, if node reports it.
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	c.chainMu.Lock()
+	defer c.chainMu.Unlock()
+	if c.chainVerified {
+		return c.chainID, nil
+	}
+	var node *big.Int
+	if reader, ok := c.client.(chainIDReader); ok {
+		var err error
+		node, err = reader.ChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case node == nil && c.chainID == nil:
+		return nil, ErrChainIDUnknown
+	case c.chainID == nil:
+		c.chainID = node
+	case node != nil && node.Cmp(c.chainID) != 0:
+		return nil, fmt.Errorf("%w: configured %v, node %v", ErrChainIDMismatch, c.chainID, node)
+	}
+	c.chainVerified = true
+	return c.chainID, nil
+}
@@ -2,12 +2,13 @@ package staking
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -17,17 +18,6 @@ import (
 var (
 	zero = big.NewInt(0)
 	one  = big.NewInt(1)
-
-	// ErrTransactionReverted raised when transaction was mined but has failed status.
-	ErrTransactionReverted = errors.New("transaction reverted")
-	// ErrInsufficientStake raised if delegated amount smaller than configured min delegation.
-	ErrInsufficientStake = errors.New("insufficient stake")
-	// ErrAlreadyRegistered raised if transcoder was already registered.
-	ErrAlreadyRegistered = errors.New("already registered")
-	// ErrNoPendingWithdrawals when there are not available withdrawals to complete.
-	ErrNoPendingWithdrawals = errors.New("no pending withdrawals aviable")
-	// ErrTranscoderNotRegistered is raised when transcoder wasn't registered
-	ErrTranscoderNotRegistered = errors.New("not registered")
 )
 
 // ETHBackend is a subset of ethereum rpc methods that are used in staking Client.
@@ -35,211 +25,436 @@ type ETHBackend interface {
 	bind.ContractBackend
 	TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error)
 	HeaderByNumber(context.Context, *big.Int) (*types.Header, error)
+	TransactionByHash(context.Context, common.Hash) (*types.Transaction, bool, error)
+	BalanceAt(context.Context, common.Address, *big.Int) (*big.Int, error)
 }
 
-func NewClient(client ETHBackend, address common.Address) (*Client, error) {
+func NewClient(client ETHBackend, address common.Address, opts ...ClientOption) (*Client, error) {
+	c := &Client{address: address}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.retryPolicy != nil {
+		client = newRetryBackend(client, *c.retryPolicy)
+		if c.batchCaller != nil {
+			c.batchCaller = retryBatchCaller{BatchCaller: c.batchCaller, policy: *c.retryPolicy}
+		}
+	}
 	contract, err := staking.NewStakingManager(address, client)
 	if err != nil {
 		return nil, err
 	}
-	return &Client{
-		client:   client,
-		contract: contract,
-	}, nil
+	parsed, err := abi.JSON(strings.NewReader(staking.StakingManagerABI))
+	if err != nil {
+		return nil, err
+	}
+	c.client = client
+	c.abi = parsed
+	c.bound = bind.NewBoundContract(address, parsed, client, client, client)
+	c.contract = contract
+	c.nonces = newNonceManager(client)
+	return c, nil
 }
 
 type Client struct {
 	client   ETHBackend
+	address  common.Address
+	abi      abi.ABI
+	bound    *bind.BoundContract
 	contract *staking.StakingManager
+	nonces   *nonceManager
+
+	bumpPolicy *BumpPolicy
+	journal    Journal
+	txDefaults []TxOption
+	observers  []Observer
+	// retryPolicy is applied to the backend when client is created.
+	retryPolicy *RetryPolicy
+	// fetchConcurrency is a number of transcoders fetched concurrently.
+	fetchConcurrency int
+	// batchCaller and multicall group transcoder reads into fewer requests, see WithBatchCaller and WithMulticall.
+	batchCaller BatchCaller
+	multicall   *common.Address
+
+	chainMu       sync.Mutex
+	chainID       *big.Int
+	chainVerified bool
 }
 
-func (c *Client) GetUnbondingPeriod(ctx context.Context) (*big.Int, error) {
-	return c.contract.UnbondingPeriod(&bind.CallOpts{Context: ctx})
+func (c *Client) GetUnbondingPeriod(ctx context.Context, opts ...ReadOption) (*big.Int, error) {
+	return c.contract.UnbondingPeriod(c.callOpts(ctx, opts))
 }
 
-func (c *Client) GetMinDelegation(ctx context.Context) (*big.Int, error) {
-	return c.contract.MinDelegation(&bind.CallOpts{Context: ctx})
+func (c *Client) GetMinDelegation(ctx context.Context, opts ...ReadOption) (*big.Int, error) {
+	return c.contract.MinDelegation(c.callOpts(ctx, opts))
 }
 
-func (c *Client) GetRequiredSelfStake(ctx context.Context) (*big.Int, error) {
-	return c.contract.MinSelfStake(&bind.CallOpts{Context: ctx})
+func (c *Client) GetRequiredSelfStake(ctx context.Context, opts ...ReadOption) (*big.Int, error) {
+	return c.contract.MinSelfStake(c.callOpts(ctx, opts))
 }
 
-func (c *Client) IsTranscoderRegistered(ctx context.Context, address common.Address) (bool, error) {
-	info, err := c.contract.Transcoders(&bind.CallOpts{Context: ctx}, address)
+func (c *Client) IsTranscoderRegistered(ctx context.Context, address common.Address, opts ...ReadOption) (bool, error) {
+	info, err := c.contract.Transcoders(c.callOpts(ctx, opts), address)
 	if err != nil {
 		return false, err
 	}
 	return info.Timestamp.Int64() != 0, nil
 }
 
-func (c *Client) GetTranscoderState(ctx context.Context, address common.Address) (State, error) {
-	state, err := c.contract.GetTranscoderState(&bind.CallOpts{Context: ctx}, address)
+func (c *Client) GetTranscoderState(ctx context.Context, address common.Address, opts ...ReadOption) (State, error) {
+	state, err := c.contract.GetTranscoderState(c.callOpts(ctx, opts), address)
 	if err != nil {
 		return 0, err
 	}
 	return State(state), nil
 }
 
-func (c *Client) GetTranscoderStake(ctx context.Context, address common.Address) (*big.Int, error) {
-	return c.contract.GetTotalStake(&bind.CallOpts{Context: ctx}, address)
+func (c *Client) GetTranscoderStake(ctx context.Context, address common.Address, opts ...ReadOption) (*big.Int, error) {
+	return c.contract.GetTotalStake(c.callOpts(ctx, opts), address)
 }
 
-func (c *Client) GetDelegatorStake(ctx context.Context, transcoder, delegator common.Address) (*big.Int, error) {
-	return c.contract.GetDelegatorStake(&bind.CallOpts{Context: ctx}, transcoder, delegator)
+func (c *Client) GetDelegatorStake(ctx context.Context, transcoder, delegator common.Address, opts ...ReadOption) (*big.Int, error) {
+	return c.contract.GetDelegatorStake(c.callOpts(ctx, opts), transcoder, delegator)
 }
 
-func (c *Client) GetTranscoderCapacity(ctx context.Context, address common.Address) (*big.Int, error) {
-	info, err := c.contract.Transcoders(&bind.CallOpts{Context: ctx}, address)
+func (c *Client) GetTranscoderCapacity(ctx context.Context, address common.Address, opts ...ReadOption) (*big.Int, error) {
+	info, err := c.contract.Transcoders(c.callOpts(ctx, opts), address)
 	if err != nil {
 		return nil, err
 	}
 	return info.Capacity, nil
 }
 
-func (c *Client) GetTranscoder(ctx context.Context, address common.Address) (tcr Transcoder, err error) {
-	info, err := c.contract.Transcoders(&bind.CallOpts{Context: ctx}, address)
+func (c *Client) GetTranscoder(ctx context.Context, address common.Address, opts ...ReadOption) (tcr Transcoder, err error) {
+	info, err := c.contract.Transcoders(c.callOpts(ctx, opts), address)
 	if err != nil {
 		return tcr, err
 	}
 	if info.Timestamp == nil || info.Timestamp.Cmp(zero) == 0 {
-		return tcr, fmt.Errorf("%w: %s", ErrTranscoderNotRegistered, address.String())
+		return tcr, &TranscoderError{Transcoder: address, Err: ErrTranscoderNotRegistered}
 	}
-	state, err := c.GetTranscoderState(ctx, address)
+	state, err := c.GetTranscoderState(ctx, address, opts...)
 	if err != nil {
 		return tcr, err
 	}
-	selfStake, err := c.contract.GetSelfStake(&bind.CallOpts{Context: ctx}, address)
+	selfStake, err := c.contract.GetSelfStake(c.callOpts(ctx, opts), address)
 	if err != nil {
 		return tcr, err
 	}
-	delegated := new(big.Int).Sub(info.Total, selfStake)
-	timestamp := info.Timestamp.Uint64()
+	return newTranscoder(address, info, state, selfStake), nil
+}
+
+// transcoderInfo is a result of the transcoders getter of the contract.
+type transcoderInfo struct {
+	Total                 *big.Int
+	Capacity              *big.Int
+	RewardRate            *big.Int
+	Timestamp             *big.Int
+	EffectiveMinSelfStake *big.Int
+}
+
+func newTranscoder(address common.Address, info transcoderInfo, state State, selfStake *big.Int) Transcoder {
 	return Transcoder{
 		Address:               address,
 		TotalStake:            info.Total,
 		SelfStake:             selfStake,
-		DelegatedStake:        delegated,
+		DelegatedStake:        new(big.Int).Sub(info.Total, selfStake),
 		Capacity:              info.Capacity,
 		State:                 state,
-		Timestamp:             timestamp,
+		Timestamp:             info.Timestamp.Uint64(),
 		EffectiveMinSelfStake: info.EffectiveMinSelfStake,
-	}, nil
+	}
 }
 
-func (c *Client) TranscodersCount(ctx context.Context) (*big.Int, error) {
-	return c.contract.TranscodersCount(&bind.CallOpts{Context: ctx})
+func (c *Client) TranscodersCount(ctx context.Context, opts ...ReadOption) (*big.Int, error) {
+	return c.contract.TranscodersCount(c.callOpts(ctx, opts))
 }
 
-func (c *Client) GetTranscoderAt(ctx context.Context, index *big.Int) (tcr Transcoder, err error) {
-	address, err := c.contract.TranscodersArray(&bind.CallOpts{Context: ctx}, index)
+func (c *Client) GetTranscoderAt(ctx context.Context, index *big.Int, opts ...ReadOption) (tcr Transcoder, err error) {
+	address, err := c.contract.TranscodersArray(c.callOpts(ctx, opts), index)
 	if err != nil {
 		return tcr, err
 	}
-	return c.GetTranscoder(ctx, address)
+	return c.GetTranscoder(ctx, address, opts...)
 }
 
-func (c *Client) TranscoderIterator(ctx context.Context) (*TranscoderIterator, error) {
-	count, err := c.TranscodersCount(ctx)
+// TranscoderIterator iterates over all transcoders at the same block, so that all of them are consistent.
+// If block is not set with AtBlock iterator is pinned to the head block.
+func (c *Client) TranscoderIterator(ctx context.Context, opts ...ReadOption) (*TranscoderIterator, error) {
+	opts, err := c.pinHead(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
-	return newTranscoderIterator(c, new(big.Int), count), nil
-}
-
-func (c *Client) GetAllTranscoders(ctx context.Context) (tcrs []Transcoder, err error) {
-	iter, err := c.TranscoderIterator(ctx)
+	count, err := c.TranscodersCount(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
-	for iter.Next(ctx) {
-		tcrs = append(tcrs, iter.Current())
-	}
-	if iter.Error() != nil {
-		return nil, iter.Error()
-	}
-	return tcrs, nil
+	return newTranscoderIterator(c, new(big.Int), count, opts), nil
+}
+
+// GetAllTranscoders returns all transcoders at the same block, head block unless set with AtBlock.
+func (c *Client) GetAllTranscoders(ctx context.Context, opts ...ReadOption) (tcrs []Transcoder, err error) {
+	return c.fetchTranscoders(ctx, opts)
 }
 
-func (c *Client) GetBondedTranscoders(ctx context.Context) (tcrs []Transcoder, err error) {
-	iter, err := c.TranscoderIterator(ctx)
+// GetBondedTranscoders returns bonded transcoders at the same block, head block unless set with AtBlock.
+func (c *Client) GetBondedTranscoders(ctx context.Context, opts ...ReadOption) (tcrs []Transcoder, err error) {
+	all, err := c.fetchTranscoders(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
-	for iter.Next(ctx) {
-		tcr := iter.Current()
+	for _, tcr := range all {
 		if tcr.State != StateBonded {
 			continue
 		}
 		tcrs = append(tcrs, tcr)
 	}
-	if iter.Error() != nil {
-		return nil, iter.Error()
-	}
 	return tcrs, nil
 }
 
-// HeadTimestamp returns timestamp of the head block. Can be used to compare with various timestamp
-// returned to the caller, e.g. transcoder Timestamp or withdrawal ReadinessTimestamp.
-func (c *Client) HeadTimestamp(ctx context.Context) (uint64, error) {
-	header, err := c.client.HeaderByNumber(ctx, nil)
+// HeadTimestamp returns timestamp of the head block, or the block set with AtBlock. Can be used to compare
+// with various timestamp returned to the caller, e.g. transcoder Timestamp or withdrawal ReadinessTimestamp.
+func (c *Client) HeadTimestamp(ctx context.Context, opts ...ReadOption) (uint64, error) {
+	header, err := c.client.HeaderByNumber(ctx, c.callOpts(ctx, opts).BlockNumber)
 	if err != nil {
 		return 0, err
 	}
 	return header.Time, nil
 }
 
-func (c *Client) Delegate(ctx context.Context, key *ecdsa.PrivateKey, to common.Address, amount *big.Int) error {
+// transactOpts returns options that sign transactions with the signer for the chain.
+func (c *Client) transactOpts(ctx context.Context, signer Signer, kind TxKind, chainID *big.Int) *bind.TransactOpts {
+	from := signer.Address()
+	return &bind.TransactOpts{
+		From:    from,
+		Context: ctx,
+		Signer: func(_ types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, fmt.Errorf("signer for 0x%x can't sign transaction from 0x%x", from, address)
+			}
+			return c.sign(kind, signer, tx, chainID)
+		},
+	}
+}
+
+// transact signs and broadcasts transaction created by fn using nonce assigned by the nonce manager.
+// If client has a journal signed transaction is recorded before it is broadcasted.
+func (c *Client) transact(ctx context.Context, signer Signer, kind TxKind, cfg txConfig,
+	fn func(*bind.TransactOpts) (*types.Transaction, error)) (tx *types.Transaction, err error) {
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := c.transactOpts(ctx, signer, kind, chainID)
+	recorded := false
+	if c.journal != nil {
+		sign := opts.Signer
+		opts.Signer = func(s types.Signer, address common.Address, unsigned *types.Transaction) (*types.Transaction, error) {
+			signed, err := sign(s, address, unsigned)
+			if err != nil {
+				return nil, err
+			}
+			err = c.record(kind, address, signed, cfg)
+			recorded = err == nil
+			return signed, err
+		}
+	}
+	if cfg.nonce != nil {
+		opts.Nonce = new(big.Int).SetUint64(*cfg.nonce)
+		tx, err = fn(opts)
+		// nonce of the account was used bypassing nonce manager.
+		c.nonces.Reset(opts.From)
+	} else {
+		err = c.nonces.Send(ctx, opts.From, func(nonce uint64) error {
+			opts.Nonce = new(big.Int).SetUint64(nonce)
+			tx, err = fn(opts)
+			return err
+		})
+	}
+	if err != nil && recorded && rejected(err) {
+		_ = c.journal.Delete(opts.From, opts.Nonce.Uint64())
+	}
+	if err == nil {
+		c.notifyTx(TxBroadcasted, kind, opts.From, tx)
+	}
+	return tx, err
+}
+
+// submit broadcasts the call, simulating it beforehand if requested.
+func (c *Client) submit(ctx context.Context, signer Signer, call Call, cfg txConfig) (*types.Transaction, error) {
+	if cfg.simulate {
+		if err := c.Simulate(ctx, signer.Address(), call); err != nil {
+			return nil, err
+		}
+	}
+	gas, gasPrice, err := c.checkFunds(ctx, signer.Address(), call, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(ctx, signer, call.Kind, cfg, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		opts.Value = call.Value
+		opts.GasLimit = gas
+		opts.GasPrice = gasPrice
+		return c.bound.Transact(opts, call.method, call.args...)
+	})
+}
+
+// checkFunds decides gas and gas price for the call and ensures that sender balance covers
+// value and fee. Returns *InsufficientBalanceError otherwise.
+func (c *Client) checkFunds(ctx context.Context, from common.Address, call Call, cfg txConfig) (gas uint64, gasPrice *big.Int, err error) {
+	value := call.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	balance, err := c.client.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	// node fails to estimate gas if value can't be covered, fee is estimated for the call without value
+	if balance.Cmp(value) < 0 {
+		required := new(big.Int).Set(value)
+		unfunded := call
+		unfunded.Value = nil
+		if gas, gasPrice, err := c.fees(ctx, from, unfunded, cfg); err == nil {
+			required = required.Add(required, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas)))
+		}
+		return 0, nil, &InsufficientBalanceError{Account: from, Balance: balance, Required: required}
+	}
+	gas, gasPrice, err = c.fees(ctx, from, call, cfg)
+	if err != nil {
+		return 0, nil, err
+	}
+	required := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas))
+	required = required.Add(required, value)
+	if balance.Cmp(required) < 0 {
+		return 0, nil, &InsufficientBalanceError{Account: from, Balance: balance, Required: required}
+	}
+	return gas, gasPrice, nil
+}
+
+// ResetNonce drops locally tracked nonce for the address. Should be used if transactions from
+// the same address were sent bypassing this client.
+func (c *Client) ResetNonce(address common.Address) {
+	c.nonces.Reset(address)
+}
+
+// SubmitDelegate broadcasts delegation of the amount to the transcoder and returns without waiting
+// for transaction to be mined.
+func (c *Client) SubmitDelegate(ctx context.Context, signer Signer, to common.Address, amount *big.Int, opts ...TxOption) (*PendingTx, error) {
+	if err := c.checkDelegate(ctx, to, amount); err != nil {
+		return nil, err
+	}
+	cfg := c.newTxConfig(opts)
+	tx, err := c.submit(ctx, signer, DelegateCall(to, amount), cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.newPendingTx(TxDelegate, signer.Address(), signer, tx, cfg,
+		fmt.Sprintf("failed to delegate from 0x%x to 0x%x. amount %v", signer.Address(), to, amount),
+	), nil
+}
+
+func (c *Client) checkDelegate(ctx context.Context, to common.Address, amount *big.Int) error {
 	min, err := c.GetMinDelegation(ctx)
 	if err != nil {
 		return err
 	}
 	if amount.Cmp(min) < 0 {
-		return fmt.Errorf("%w: amount %v is smaller than required delegation %v",
-			ErrInsufficientStake, amount, min)
+		return &InsufficientStakeError{Transcoder: to, Amount: amount, Required: min}
 	}
-	opts := bind.NewKeyedTransactor(key)
-	opts.Context = ctx
-	opts.Value = amount
-	tx, err := c.contract.Delegate(opts, to)
+	return nil
+}
+
+// checkDelegateTarget ensures that transcoder is registered and amount is not lower than min delegation.
+func (c *Client) checkDelegateTarget(ctx context.Context, to common.Address, amount *big.Int) error {
+	reg, err := c.IsTranscoderRegistered(ctx, to)
 	if err != nil {
 		return err
 	}
-	receipt, err := bind.WaitMined(ctx, c.client, tx)
+	if !reg {
+		return &TranscoderError{Transcoder: to, Err: ErrTranscoderNotRegistered}
+	}
+	return c.checkDelegate(ctx, to, amount)
+}
+
+func (c *Client) Delegate(ctx context.Context, signer Signer, to common.Address, amount *big.Int, opts ...TxOption) (TxResult, error) {
+	tx, err := c.SubmitDelegate(ctx, signer, to, amount, opts...)
+	if err != nil {
+		return TxResult{}, err
+	}
+	return tx.Wait(ctx)
+}
+
+// SubmitRegisterTranscoder broadcasts transcoder registration and returns without waiting
+// for transaction to be mined.
+func (c *Client) SubmitRegisterTranscoder(ctx context.Context, signer Signer, rewardRate uint64, opts ...TxOption) (*PendingTx, error) {
+	from := signer.Address()
+	if err := c.checkRegisterTranscoder(ctx, from); err != nil {
+		return nil, err
+	}
+	cfg := c.newTxConfig(opts)
+	tx, err := c.submit(ctx, signer, RegisterTranscoderCall(rewardRate), cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.newPendingTx(TxRegisterTranscoder, from, signer, tx, cfg,
+		fmt.Sprintf("failed to register 0x%x with rate %d", from, rewardRate),
+	), nil
+}
+
+func (c *Client) checkRegisterTranscoder(ctx context.Context, from common.Address) error {
+	reg, err := c.IsTranscoderRegistered(ctx, from)
 	if err != nil {
 		return err
 	}
-	if receipt.Status == types.ReceiptStatusFailed {
-		return fmt.Errorf("%w: failed to delegate from 0x%x to 0x%x. amount %v",
-			ErrTransactionReverted, opts.From, to, amount,
-		)
+	if reg {
+		return &TranscoderError{Transcoder: from, Err: ErrAlreadyRegistered}
 	}
 	return nil
 }
 
 // RegisterTranscoder ensures that transcoder is registered. If transcoder already registered
 // new reward rate is not applied.
-func (c *Client) RegisterTranscoder(ctx context.Context, key *ecdsa.PrivateKey, rewardRate uint64) error {
-	opts := bind.NewKeyedTransactor(key)
-	opts.Context = ctx
-	reg, err := c.IsTranscoderRegistered(ctx, opts.From)
+func (c *Client) RegisterTranscoder(ctx context.Context, signer Signer, rewardRate uint64, opts ...TxOption) (TxResult, error) {
+	tx, err := c.SubmitRegisterTranscoder(ctx, signer, rewardRate, opts...)
 	if err != nil {
-		return err
+		return TxResult{}, err
 	}
-	if reg {
-		return fmt.Errorf("0x%x %w", opts.From, ErrAlreadyRegistered)
+	return tx.Wait(ctx)
+}
+
+// SubmitRequestWithdrawal broadcasts unbonding request and returns without waiting
+// for transaction to be mined.
+func (c *Client) SubmitRequestWithdrawal(ctx context.Context,
+	signer Signer,
+	from common.Address,
+	amount *big.Int,
+	opts ...TxOption) (*PendingWithdrawal, error) {
+	if err := c.checkRequestWithdrawal(ctx, signer.Address(), from, amount); err != nil {
+		return nil, err
 	}
-	tx, err := c.contract.RegisterTranscoder(opts, new(big.Int).SetUint64(rewardRate))
+	cfg := c.newTxConfig(opts)
+	tx, err := c.submit(ctx, signer, RequestWithdrawalCall(from, amount), cfg)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	receipt, err := bind.WaitMined(ctx, c.client, tx)
+	return &PendingWithdrawal{PendingTx: c.newPendingTx(TxRequestWithdrawal, signer.Address(), signer, tx, cfg,
+		fmt.Sprintf("failed to request withdrawal from 0x%x. amount %v", from, amount),
+	)}, nil
+}
+
+func (c *Client) checkRequestWithdrawal(ctx context.Context, delegator, from common.Address, amount *big.Int) error {
+	delegated, err := c.contract.GetDelegatorStake(&bind.CallOpts{Context: ctx}, from, delegator)
 	if err != nil {
 		return err
 	}
-	if receipt.Status == types.ReceiptStatusFailed {
-		return fmt.Errorf("%w: failed to register 0x%x with rate %d",
-			ErrTransactionReverted, opts.From, rewardRate)
+	if delegated.Cmp(amount) < 0 {
+		return &OverWithdrawalError{
+			Transcoder: from,
+			Delegator:  delegator,
+			Requested:  amount,
+			Delegated:  delegated,
+		}
 	}
 	return nil
 }
@@ -248,86 +463,58 @@ func (c *Client) RegisterTranscoder(ctx context.Context, key *ecdsa.PrivateKey,
 // or completes withdrawal immediatly if transcoder is not BONDED/UNBONDING. In the latter case Amount will be non-nil.
 // And ReadinessTimestamp is 0.
 func (c *Client) RequestWithdrawal(ctx context.Context,
-	key *ecdsa.PrivateKey,
+	signer Signer,
 	from common.Address,
-	amount *big.Int) (info WithdrawalInfo, err error) {
-	opts := bind.NewKeyedTransactor(key)
-	delegated, err := c.contract.GetDelegatorStake(&bind.CallOpts{Context: ctx}, from, opts.From)
+	amount *big.Int,
+	opts ...TxOption) (info WithdrawalInfo, err error) {
+	tx, err := c.SubmitRequestWithdrawal(ctx, signer, from, amount, opts...)
 	if err != nil {
 		return info, err
 	}
-	if delegated.Cmp(amount) < 0 {
-		return info, fmt.Errorf("can't withdraw. delegated amount %v is less than requested %v", delegated, amount)
+	return tx.Wait(ctx)
+}
+
+// SubmitCompleteWithdrawals broadcasts completion of all pending withdrawals and returns without waiting
+// for transaction to be mined.
+func (c *Client) SubmitCompleteWithdrawals(ctx context.Context, signer Signer, opts ...TxOption) (*PendingWithdrawal, error) {
+	if err := c.checkCompleteWithdrawals(ctx, signer.Address()); err != nil {
+		return nil, err
 	}
-	tx, err := c.contract.RequestUnbonding(opts, from, amount)
+	cfg := c.newTxConfig(opts)
+	tx, err := c.submit(ctx, signer, CompleteWithdrawalsCall(), cfg)
 	if err != nil {
-		return info, err
+		return nil, err
 	}
-	receipt, err := bind.WaitMined(ctx, c.client, tx)
+	return &PendingWithdrawal{PendingTx: c.newPendingTx(TxCompleteWithdrawals, signer.Address(), signer, tx, cfg,
+		"failed to complete pending withdrawals",
+	)}, nil
+}
+
+func (c *Client) checkCompleteWithdrawals(ctx context.Context, from common.Address) error {
+	pending, err := c.contract.PendingWithdrawalsExist(&bind.CallOpts{Context: ctx, From: from})
 	if err != nil {
-		return info, err
-	}
-	if receipt.Status == types.ReceiptStatusFailed {
-		return info, fmt.Errorf("%w: failed to request withdrawal from 0x%x. amount %v",
-			ErrTransactionReverted, from, amount,
-		)
-	}
-	if len(receipt.Logs) > 0 {
-		unbonding, err := c.contract.ParseUnbondingRequested(*receipt.Logs[0])
-		if err != nil {
-			return info, err
-		}
-		info.ReadinessTimestamp = unbonding.Readiness.Uint64()
+		return err
 	}
-	if len(receipt.Logs) > 1 {
-		withdraw, err := c.contract.ParseStakeWithdrawal(*receipt.Logs[1])
-		if err != nil {
-			return info, err
-		}
-		info.Amount = withdraw.Amount
-		info.ReadinessTimestamp = 0 // set timestamp to 0 cause request was already completed.
+	if !pending {
+		return ErrNoPendingWithdrawals
 	}
-	return info, nil
+	return nil
 }
 
 // CompleteWithdrawals completes all pending withdrawals, if any are available. All amounts from withdrawals
 // are accumulated into info.Amount.
-func (c *Client) CompleteWithdrawals(ctx context.Context, key *ecdsa.PrivateKey) (info WithdrawalInfo, err error) {
-	opts := bind.NewKeyedTransactor(key)
-	pending, err := c.contract.PendingWithdrawalsExist(&bind.CallOpts{Context: ctx, From: opts.From})
-	if err != nil {
-		return info, err
-	}
-	if !pending {
-		return info, ErrNoPendingWithdrawals
-	}
-	tx, err := c.contract.WithdrawAllPending(opts)
-	if err != nil {
-		return info, err
-	}
-	receipt, err := bind.WaitMined(ctx, c.client, tx)
+func (c *Client) CompleteWithdrawals(ctx context.Context, signer Signer, opts ...TxOption) (info WithdrawalInfo, err error) {
+	tx, err := c.SubmitCompleteWithdrawals(ctx, signer, opts...)
 	if err != nil {
 		return info, err
 	}
-	if receipt.Status == types.ReceiptStatusFailed {
-		return info, fmt.Errorf("%w: failed to complete pending withdrawals", ErrTransactionReverted)
-	}
-	amount := big.NewInt(0)
-	for _, log := range receipt.Logs {
-		withdraw, err := c.contract.ParseStakeWithdrawal(*log)
-		if err != nil {
-			return info, err
-		}
-		amount = amount.Add(amount, withdraw.Amount)
-	}
-	info.Amount = amount
-	return info, err
+	return tx.Wait(ctx)
 }
 
 // WaitWithdrawalsCompleted exits either when some withdrawals were completed or by context timeout. It should not be executed
 // concurrently with another WaitWithdrawalsCompleted/CompleteWithdrawals.
-func (c *Client) WaitWithdrawalsCompleted(ctx context.Context, key *ecdsa.PrivateKey) (info WithdrawalInfo, err error) {
-	info, err = c.CompleteWithdrawals(ctx, key)
+func (c *Client) WaitWithdrawalsCompleted(ctx context.Context, signer Signer, opts ...TxOption) (info WithdrawalInfo, err error) {
+	info, err = c.CompleteWithdrawals(ctx, signer, opts...)
 	if err == nil {
 		return info, err
 	}
@@ -338,7 +525,7 @@ func (c *Client) WaitWithdrawalsCompleted(ctx context.Context, key *ecdsa.Privat
 		case <-ctx.Done():
 			return info, ctx.Err()
 		case <-ticker.C:
-			info, err = c.CompleteWithdrawals(ctx, key)
+			info, err = c.CompleteWithdrawals(ctx, signer, opts...)
 			if err == nil {
 				return info, nil
 			}
@@ -346,9 +533,10 @@ func (c *Client) WaitWithdrawalsCompleted(ctx context.Context, key *ecdsa.Privat
 	}
 }
 
-func newTranscoderIterator(client *Client, start, end *big.Int) *TranscoderIterator {
+func newTranscoderIterator(client *Client, start, end *big.Int, opts []ReadOption) *TranscoderIterator {
 	return &TranscoderIterator{
 		client: client,
+		opts:   opts,
 		start:  start,
 		end:    end,
 	}
@@ -356,6 +544,7 @@ func newTranscoderIterator(client *Client, start, end *big.Int) *TranscoderItera
 
 type TranscoderIterator struct {
 	client *Client
+	opts   []ReadOption
 
 	start, end *big.Int
 
@@ -367,7 +556,7 @@ func (iter *TranscoderIterator) Next(ctx context.Context) bool {
 	if iter.start.Cmp(iter.end) >= 0 || iter.err != nil {
 		return false
 	}
-	tcr, err := iter.client.GetTranscoderAt(ctx, iter.start)
+	tcr, err := iter.client.GetTranscoderAt(ctx, iter.start, iter.opts...)
 	iter.err = err
 	if err != nil {
 		return false
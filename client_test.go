@@ -3,20 +3,34 @@ package staking
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"math/big"
+	"os"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/suite"
 	"github.com/videocoin/go-contracts/bindings/staking"
 )
 
+// simulatedChainID is a chain id of the simulated backend, which doesn't report it.
+var simulatedChainID = params.AllEthashProtocolChanges.ChainID
+
 type StakingSuite struct {
 	suite.Suite
 
@@ -66,13 +80,15 @@ type ClientSuite struct {
 
 	ctx    context.Context
 	cancel func()
+	// mining is held by the background miner while it commits a block.
+	mining sync.Mutex
 
 	StakingClient *Client
 }
 
 func (s *ClientSuite) SetupTest() {
 	s.StakingSuite.SetupTest()
-	client, err := NewClient(s.Backend, s.ContractAddress)
+	client, err := NewClient(s.Backend, s.ContractAddress, WithChainID(simulatedChainID))
 	s.Require().NoError(err)
 	s.StakingClient = client
 	s.ctx, s.cancel = context.WithCancel(context.Background())
@@ -82,12 +98,21 @@ func (s *ClientSuite) SetupTest() {
 			case <-s.ctx.Done():
 				return
 			default:
+				s.mining.Lock()
 				s.Backend.Commit()
+				s.mining.Unlock()
 			}
 		}
 	}()
 }
 
+// pauseMining stops background mining until returned function is called. Simulated backend executes
+// calls only on the latest block, reads pinned to the block fail if it changes.
+func (s *ClientSuite) pauseMining() func() {
+	s.mining.Lock()
+	return s.mining.Unlock
+}
+
 func (s *ClientSuite) TeatDownTest() {
 	s.StakingSuite.TearDownTest()
 	s.cancel()
@@ -95,11 +120,13 @@ func (s *ClientSuite) TeatDownTest() {
 
 func (s *ClientSuite) TestGetTrascoders() {
 	for _, pkey := range s.FundedKeys {
-		err := s.StakingClient.RegisterTranscoder(context.Background(), pkey, 10)
+		_, err := s.StakingClient.RegisterTranscoder(context.Background(), NewKeySigner(pkey), 10)
 		s.Require().NoError(err)
 	}
 
+	resume := s.pauseMining()
 	transcoders, err := s.StakingClient.GetAllTranscoders(context.Background())
+	resume()
 	s.Require().NoError(err)
 	s.Require().Len(transcoders, len(s.FundedKeys))
 
@@ -113,12 +140,12 @@ func (s *ClientSuite) TestGetTrascoders() {
 func (s *ClientSuite) TestTranscoderWithdraw() {
 	transcoder := s.FundedKeys[0]
 
-	err := s.StakingClient.RegisterTranscoder(s.ctx, transcoder, 10)
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(transcoder), 10)
 	s.Require().NoError(err)
 
 	// delegate enough funds to transition to bonded state
 	addr := crypto.PubkeyToAddress(transcoder.PublicKey)
-	err = s.StakingClient.Delegate(s.ctx, transcoder, addr, big.NewInt(1e15))
+	_, err = s.StakingClient.Delegate(s.ctx, NewKeySigner(transcoder), addr, big.NewInt(1e15))
 	s.Require().NoError(err)
 
 	state, err := s.StakingClient.GetTranscoderState(context.TODO(), addr)
@@ -126,48 +153,48 @@ func (s *ClientSuite) TestTranscoderWithdraw() {
 	s.Require().Equal(StateBonded, state)
 
 	amount := big.NewInt(1e14)
-	info, err := s.StakingClient.RequestWithdrawal(s.ctx, transcoder, addr, amount)
+	info, err := s.StakingClient.RequestWithdrawal(s.ctx, NewKeySigner(transcoder), addr, amount)
 	s.Require().NoError(err)
 	s.Require().Nil(info.Amount)
 	s.Require().NotEqual(info.ReadinessTimestamp, 0)
 
-	info, err = s.StakingClient.CompleteWithdrawals(s.ctx, transcoder)
+	info, err = s.StakingClient.CompleteWithdrawals(s.ctx, NewKeySigner(transcoder))
 	s.Require().NoError(err)
 	s.Require().NotNil(info.Amount)
 	s.Require().Equal(amount.Int64(), info.Amount.Int64())
 
-	_, err = s.StakingClient.CompleteWithdrawals(s.ctx, transcoder)
+	_, err = s.StakingClient.CompleteWithdrawals(s.ctx, NewKeySigner(transcoder))
 	s.Require().True(errors.Is(err, ErrNoPendingWithdrawals))
 }
 
 func (s *ClientSuite) TestRequestCompletedImmediatly() {
 	transcoder := s.FundedKeys[0]
 
-	err := s.StakingClient.RegisterTranscoder(s.ctx, transcoder, 10)
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(transcoder), 10)
 	s.Require().NoError(err)
 
 	amount := big.NewInt(50)
 
 	// delegate enough funds to transition to bonded state
 	addr := crypto.PubkeyToAddress(transcoder.PublicKey)
-	err = s.StakingClient.Delegate(s.ctx, transcoder, addr, amount)
+	_, err = s.StakingClient.Delegate(s.ctx, NewKeySigner(transcoder), addr, amount)
 	s.Require().NoError(err)
 
-	info, err := s.StakingClient.RequestWithdrawal(s.ctx, transcoder, addr, amount)
+	info, err := s.StakingClient.RequestWithdrawal(s.ctx, NewKeySigner(transcoder), addr, amount)
 	s.Require().NoError(err)
 	s.Require().Equal(amount.Int64(), info.Amount.Int64())
 	s.Require().Empty(info.ReadinessTimestamp)
 }
 
 func (s *ClientSuite) TestDelegatedStake() {
-	err := s.StakingClient.RegisterTranscoder(s.ctx, s.FundedKeys[0], 10)
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(s.FundedKeys[0]), 10)
 	s.Require().NoError(err)
 
 	amount := big.NewInt(50)
 
 	// delegate enough funds to transition to bonded state
 	addr := crypto.PubkeyToAddress(s.FundedKeys[0].PublicKey)
-	err = s.StakingClient.Delegate(s.ctx, s.FundedKeys[1], addr, amount)
+	_, err = s.StakingClient.Delegate(s.ctx, NewKeySigner(s.FundedKeys[1]), addr, amount)
 	s.Require().NoError(err)
 
 	transcoder, err := s.StakingClient.GetTranscoder(s.ctx, addr)
@@ -178,36 +205,38 @@ func (s *ClientSuite) TestDelegatedStake() {
 }
 
 func (s *ClientSuite) TestCompleteMultiple() {
-	err := s.StakingClient.RegisterTranscoder(s.ctx, s.FundedKeys[0], 10)
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(s.FundedKeys[0]), 10)
 	s.Require().NoError(err)
 
 	amount := big.NewInt(1000)
 
 	// delegate enough funds to transition to bonded state
 	addr := crypto.PubkeyToAddress(s.FundedKeys[0].PublicKey)
-	err = s.StakingClient.Delegate(s.ctx, s.FundedKeys[0], addr, amount)
+	_, err = s.StakingClient.Delegate(s.ctx, NewKeySigner(s.FundedKeys[0]), addr, amount)
 	s.Require().NoError(err)
 
 	for i := 0; i < 4; i++ {
-		info, err := s.StakingClient.RequestWithdrawal(s.ctx, s.FundedKeys[0], addr, big.NewInt(250))
+		info, err := s.StakingClient.RequestWithdrawal(s.ctx, NewKeySigner(s.FundedKeys[0]), addr, big.NewInt(250))
 		s.Require().NoError(err)
 		s.Require().NotEmpty(info.ReadinessTimestamp)
 	}
 
-	info, err := s.StakingClient.CompleteWithdrawals(s.ctx, s.FundedKeys[0])
+	info, err := s.StakingClient.CompleteWithdrawals(s.ctx, NewKeySigner(s.FundedKeys[0]))
 	s.Require().NoError(err)
 	s.Require().Equal(amount.Int64(), info.Amount.Int64())
 }
 
 func (s *ClientSuite) TestTransitionToBondedWithSeveralDelegates() {
-	err := s.StakingClient.RegisterTranscoder(s.ctx, s.FundedKeys[0], 10)
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(s.FundedKeys[0]), 10)
 	s.Require().NoError(err)
 	addr := crypto.PubkeyToAddress(s.FundedKeys[0].PublicKey)
 	for i := 0; i < 3; i++ {
-		err := s.StakingClient.Delegate(s.ctx, s.FundedKeys[0], addr, big.NewInt(40))
+		_, err := s.StakingClient.Delegate(s.ctx, NewKeySigner(s.FundedKeys[0]), addr, big.NewInt(40))
 		s.Require().NoError(err)
 	}
+	resume := s.pauseMining()
 	transcoders, err := s.StakingClient.GetBondedTranscoders(s.ctx)
+	resume()
 	s.Require().NoError(err)
 	s.Require().Len(transcoders, 1)
 	s.Require().Equal(addr, transcoders[0].Address)
@@ -222,7 +251,7 @@ func (s *ClientSuite) TestGetUnregisteredTranscoderState() {
 func (s *ClientSuite) TestWaitWithdrawalCompletedTimeout() {
 	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
 	defer cancel()
-	_, err := s.StakingClient.WaitWithdrawalsCompleted(ctx, s.FundedKeys[0])
+	_, err := s.StakingClient.WaitWithdrawalsCompleted(ctx, NewKeySigner(s.FundedKeys[0]))
 	s.Require().True(errors.Is(err, context.DeadlineExceeded))
 }
 
@@ -234,21 +263,21 @@ func (s *ClientSuite) TestWaithWithdrawalCompletedSuccess() {
 	)
 	defer cancel()
 	go func() {
-		info, err := s.StakingClient.WaitWithdrawalsCompleted(ctx, s.FundedKeys[0])
+		info, err := s.StakingClient.WaitWithdrawalsCompleted(ctx, NewKeySigner(s.FundedKeys[0]))
 		s.Require().NoError(err)
 		infos <- info
 	}()
 
-	err := s.StakingClient.RegisterTranscoder(s.ctx, transcoder, 10)
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(transcoder), 10)
 	s.Require().NoError(err)
 
 	// delegate enough funds to transition to bonded state
 	addr := crypto.PubkeyToAddress(transcoder.PublicKey)
-	err = s.StakingClient.Delegate(s.ctx, transcoder, addr, big.NewInt(1e15))
+	_, err = s.StakingClient.Delegate(s.ctx, NewKeySigner(transcoder), addr, big.NewInt(1e15))
 	s.Require().NoError(err)
 
 	amount := big.NewInt(1e15)
-	info, err := s.StakingClient.RequestWithdrawal(s.ctx, transcoder, addr, amount)
+	info, err := s.StakingClient.RequestWithdrawal(s.ctx, NewKeySigner(transcoder), addr, amount)
 	s.Require().NoError(err)
 	s.Require().Nil(info.Amount)
 	s.Require().NotEqual(info.ReadinessTimestamp, 0)
@@ -262,12 +291,16 @@ func (s *ClientSuite) TestWaithWithdrawalCompletedSuccess() {
 }
 
 func (s *ClientSuite) TestEffectiveMinSelfStake() {
-	s.Require().NoError(s.StakingClient.RegisterTranscoder(s.ctx, s.FundedKeys[0], 10))
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(s.FundedKeys[0]), 10)
+	s.Require().NoError(err)
 	addr := crypto.PubkeyToAddress(s.FundedKeys[0].PublicKey)
 	original := big.NewInt(100)
-	s.Require().NoError(s.StakingClient.Delegate(s.ctx, s.FundedKeys[0], addr, original))
+	_, err = s.StakingClient.Delegate(s.ctx, NewKeySigner(s.FundedKeys[0]), addr, original)
+	s.Require().NoError(err)
 
+	resume := s.pauseMining()
 	transcoders, err := s.StakingClient.GetBondedTranscoders(s.ctx)
+	resume()
 	s.Require().NoError(err)
 	s.Require().Len(transcoders, 1)
 
@@ -275,8 +308,654 @@ func (s *ClientSuite) TestEffectiveMinSelfStake() {
 	_, err = s.Contract.SetSelfMinStake(opts, big.NewInt(1000))
 	s.Require().NoError(err)
 
+	resume = s.pauseMining()
 	transcoders, err = s.StakingClient.GetBondedTranscoders(s.ctx)
+	resume()
 	s.Require().NoError(err)
 	s.Require().Len(transcoders, 1)
 	s.Require().Equal(original, transcoders[0].EffectiveMinSelfStake)
 }
+
+func (s *ClientSuite) TestKeystoreSigner() {
+	dir, err := ioutil.TempDir("", "staking-keystore")
+	s.Require().NoError(err)
+	defer os.RemoveAll(dir)
+
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(s.FundedKeys[0], "password")
+	s.Require().NoError(err)
+
+	requested := 0
+	signer := NewKeystoreSigner(ks, account, func(accounts.Account) (string, error) {
+		requested++
+		return "password", nil
+	})
+	s.Require().Equal(crypto.PubkeyToAddress(s.FundedKeys[0].PublicKey), signer.Address())
+	_, err = s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+
+	registered, err := s.StakingClient.IsTranscoderRegistered(s.ctx, signer.Address())
+	s.Require().NoError(err)
+	s.Require().True(registered)
+	s.Require().Equal(1, requested)
+}
+
+func (s *ClientSuite) TestSubmitAndAttach() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+
+	// amount is not enough to transition to bonded state, withdrawal completes immediatly
+	amount := big.NewInt(50)
+	tx, err := s.StakingClient.SubmitDelegate(s.ctx, signer, signer.Address(), amount)
+	s.Require().NoError(err)
+	s.Require().Equal(TxDelegate, tx.Kind)
+	result, err := s.StakingClient.AttachTx(tx.Kind, tx.Hash).Wait(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(tx.Hash, result.Hash)
+	s.Require().NotEmpty(result.BlockNumber)
+	s.Require().NotEmpty(result.GasUsed)
+	s.Require().Equal(new(big.Int).Mul(result.GasPrice, new(big.Int).SetUint64(result.GasUsed)), result.Fee)
+
+	withdrawal, err := s.StakingClient.SubmitRequestWithdrawal(s.ctx, signer, signer.Address(), amount)
+	s.Require().NoError(err)
+	info, err := s.StakingClient.AttachWithdrawal(withdrawal.Kind, withdrawal.Hash).Wait(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(amount.Int64(), info.Amount.Int64())
+	s.Require().Equal(withdrawal.Hash, info.Tx.Hash)
+}
+
+func (s *ClientSuite) TestConcurrentDelegate() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+
+	var (
+		n      = 5
+		amount = big.NewInt(50)
+		errs   = make(chan error, n)
+	)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := s.StakingClient.Delegate(s.ctx, signer, signer.Address(), amount)
+			errs <- err
+		}()
+	}
+	for i := 0; i < n; i++ {
+		s.Require().NoError(<-errs)
+	}
+
+	stake, err := s.StakingClient.GetTranscoderStake(s.ctx, signer.Address())
+	s.Require().NoError(err)
+	s.Require().Equal(int64(n)*amount.Int64(), stake.Int64())
+}
+
+func (s *ClientSuite) TestWaitConfirmations() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	result, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10, WithConfirmations(3))
+	s.Require().NoError(err)
+
+	head, err := s.Backend.HeaderByNumber(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Require().True(head.Number.Uint64() >= result.BlockNumber+2)
+}
+
+func (s *ClientSuite) TestSimulateBeforeBroadcast() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.StakingClient.Simulate(s.ctx, signer.Address(), DelegateCall(signer.Address(), big.NewInt(50))))
+
+	// withdrawing stake that was never delegated reverts
+	err = s.StakingClient.Simulate(s.ctx, signer.Address(), RequestWithdrawalCall(signer.Address(), big.NewInt(50)))
+	s.Require().True(errors.Is(err, ErrTransactionReverted))
+	s.Require().True(errors.Is(err, ErrOverWithdrawal))
+	var over *OverWithdrawalError
+	s.Require().True(errors.As(err, &over))
+	s.Require().Equal(signer.Address(), over.Delegator)
+
+	err = s.StakingClient.Simulate(s.ctx, signer.Address(), RegisterTranscoderCall(10))
+	s.Require().True(errors.Is(err, ErrTransactionReverted))
+	s.Require().True(errors.Is(err, ErrAlreadyRegistered))
+
+	_, err = s.StakingClient.Delegate(s.ctx, signer, signer.Address(), big.NewInt(50), WithSimulation())
+	s.Require().NoError(err)
+}
+
+func (s *ClientSuite) TestOverWithdrawal() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+	_, err = s.StakingClient.Delegate(s.ctx, signer, signer.Address(), big.NewInt(50))
+	s.Require().NoError(err)
+
+	_, err = s.StakingClient.RequestWithdrawal(s.ctx, signer, signer.Address(), big.NewInt(51))
+	s.Require().True(errors.Is(err, ErrOverWithdrawal))
+	var over *OverWithdrawalError
+	s.Require().True(errors.As(err, &over))
+	s.Require().Equal(int64(50), over.Delegated.Int64())
+	s.Require().Equal(signer.Address(), over.Delegator)
+}
+
+func (s *ClientSuite) TestDelegateInsufficientBalance() {
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(s.FundedKeys[0]), 10)
+	s.Require().NoError(err)
+
+	key, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	amount := big.NewInt(100)
+	addr := crypto.PubkeyToAddress(s.FundedKeys[0].PublicKey)
+	_, err = s.StakingClient.Delegate(s.ctx, NewKeySigner(key), addr, amount)
+	s.Require().True(errors.Is(err, ErrInsufficientBalance))
+
+	var balanceErr *InsufficientBalanceError
+	s.Require().True(errors.As(err, &balanceErr))
+	// fee is included if it can be estimated without the value
+	s.Require().True(balanceErr.Shortfall().Cmp(amount) >= 0)
+}
+
+func (s *ClientSuite) TestResumeJournaled() {
+	journal := NewMemoryJournal()
+	client, err := NewClient(s.Backend, s.ContractAddress, WithChainID(simulatedChainID), WithJournal(journal))
+	s.Require().NoError(err)
+
+	signer := NewKeySigner(s.FundedKeys[0])
+	_, err = client.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+	entries, err := journal.Entries()
+	s.Require().NoError(err)
+	s.Require().Empty(entries)
+
+	tx, err := client.SubmitDelegate(s.ctx, signer, signer.Address(), big.NewInt(50))
+	s.Require().NoError(err)
+	entries, err = journal.Entries()
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Require().Equal(tx.Hashes(), entries[0].Hashes)
+
+	// new client imitates restart of the process.
+	restarted, err := NewClient(s.Backend, s.ContractAddress, WithChainID(simulatedChainID), WithJournal(journal))
+	s.Require().NoError(err)
+	results, err := restarted.Resume(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+	s.Require().NoError(results[0].Err)
+	s.Require().Equal(tx.Hash, results[0].Tx.Hash)
+
+	entries, err = journal.Entries()
+	s.Require().NoError(err)
+	s.Require().Empty(entries)
+}
+
+func (s *ClientSuite) TestOfflineSigning() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+
+	amount := big.NewInt(50)
+	env, err := s.StakingClient.BuildDelegate(s.ctx, signer.Address(), signer.Address(), amount)
+	s.Require().NoError(err)
+	_, err = s.StakingClient.Broadcast(s.ctx, env)
+	s.Require().Error(err)
+
+	// envelope is transferred to the offline machine and back
+	data, err := json.Marshal(env)
+	s.Require().NoError(err)
+	offline := new(Envelope)
+	s.Require().NoError(json.Unmarshal(data, offline))
+	s.Require().Error(SignEnvelope(offline, NewKeySigner(s.FundedKeys[1])))
+	s.Require().NoError(SignEnvelope(offline, signer))
+
+	tx, err := s.StakingClient.Broadcast(s.ctx, offline)
+	s.Require().NoError(err)
+	_, err = tx.Wait(s.ctx)
+	s.Require().NoError(err)
+
+	env, err = s.StakingClient.BuildRequestWithdrawal(s.ctx, signer.Address(), signer.Address(), amount)
+	s.Require().NoError(err)
+	s.Require().NoError(SignEnvelope(env, signer))
+	withdrawal, err := s.StakingClient.BroadcastWithdrawal(s.ctx, env)
+	s.Require().NoError(err)
+	info, err := withdrawal.Wait(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(amount.Int64(), info.Amount.Int64())
+}
+
+type chainIDBackend struct {
+	*backends.SimulatedBackend
+	chainID *big.Int
+}
+
+func (b chainIDBackend) ChainID(context.Context) (*big.Int, error) {
+	return b.chainID, nil
+}
+
+// chainSigner signs transactions for the fixed chain regardless of requested one.
+type chainSigner struct {
+	Signer
+	chainID *big.Int
+}
+
+func (s chainSigner) SignTx(tx *types.Transaction, _ *big.Int) (*types.Transaction, error) {
+	return s.Signer.SignTx(tx, s.chainID)
+}
+
+func (s *ClientSuite) TestChainID() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	unknown, err := NewClient(s.Backend, s.ContractAddress)
+	s.Require().NoError(err)
+	_, err = unknown.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().True(errors.Is(err, ErrChainIDUnknown))
+
+	mismatch, err := NewClient(chainIDBackend{s.Backend, simulatedChainID}, s.ContractAddress, WithChainID(big.NewInt(1)))
+	s.Require().NoError(err)
+	_, err = mismatch.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().True(errors.Is(err, ErrChainIDMismatch))
+
+	reported, err := NewClient(chainIDBackend{s.Backend, simulatedChainID}, s.ContractAddress)
+	s.Require().NoError(err)
+	result, err := reported.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+	tx, _, err := s.Backend.TransactionByHash(s.ctx, result.Hash)
+	s.Require().NoError(err)
+	s.Require().True(tx.Protected())
+	s.Require().Equal(simulatedChainID, tx.ChainId())
+
+	_, err = s.StakingClient.RegisterTranscoder(s.ctx, chainSigner{NewKeySigner(s.FundedKeys[1]), big.NewInt(1)}, 10)
+	s.Require().True(errors.Is(err, ErrChainIDMismatch))
+}
+
+func (s *ClientSuite) TestTxOptions() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	result, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10,
+		WithGasPrice(big.NewInt(3)),
+		WithGasLimit(500000),
+	)
+	s.Require().NoError(err)
+	tx, _, err := s.Backend.TransactionByHash(s.ctx, result.Hash)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(3), tx.GasPrice().Int64())
+	s.Require().Equal(uint64(500000), tx.Gas())
+
+	capped, err := NewClient(s.Backend, s.ContractAddress, WithChainID(simulatedChainID),
+		WithTxDefaults(WithGasPriceStrategy(CappedGasPrice(SuggestedGasPrice(10), big.NewInt(2)))))
+	s.Require().NoError(err)
+	result, err = capped.Delegate(s.ctx, signer, signer.Address(), big.NewInt(50))
+	s.Require().NoError(err)
+	s.Require().Equal(int64(2), result.GasPrice.Int64())
+}
+
+func (s *ClientSuite) TestEstimate() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	estimate, err := s.StakingClient.EstimateRegisterTranscoder(s.ctx, signer.Address(), 10)
+	s.Require().NoError(err)
+	s.Require().NotZero(estimate.Gas)
+	s.Require().Equal(new(big.Int).Mul(estimate.GasPrice, new(big.Int).SetUint64(estimate.Gas)), estimate.Fee)
+
+	result, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+	s.Require().True(result.GasUsed <= estimate.Gas)
+
+	_, err = s.StakingClient.EstimateCompleteWithdrawals(s.ctx, signer.Address())
+	s.Require().True(errors.Is(err, ErrNoPendingWithdrawals))
+
+	estimate, err = s.StakingClient.EstimateDelegate(s.ctx, signer.Address(), signer.Address(), big.NewInt(50),
+		WithGasPrice(big.NewInt(5)))
+	s.Require().NoError(err)
+	s.Require().Equal(int64(5), estimate.GasPrice.Int64())
+}
+
+func (s *ClientSuite) TestDelegateBatch() {
+	var delegations []Delegation
+	for i := 0; i < 3; i++ {
+		signer := NewKeySigner(s.FundedKeys[i])
+		_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+		s.Require().NoError(err)
+		delegations = append(delegations, Delegation{To: signer.Address(), Amount: big.NewInt(50)})
+	}
+	min, err := s.StakingClient.GetMinDelegation(s.ctx)
+	s.Require().NoError(err)
+	delegations = append(delegations, Delegation{To: delegations[0].To, Amount: new(big.Int).Sub(min, one)})
+
+	delegator := NewKeySigner(s.FundedKeys[3])
+	results, err := s.StakingClient.DelegateBatch(s.ctx, delegator, delegations)
+	s.Require().NoError(err)
+	s.Require().Len(results, len(delegations))
+	for i, result := range results[:3] {
+		s.Require().NoError(result.Err)
+		s.Require().Equal(delegations[i], result.Delegation)
+		if i > 0 {
+			s.Require().Greater(result.Tx.Nonce, results[i-1].Tx.Nonce)
+		}
+		stake, err := s.StakingClient.GetDelegatorStake(s.ctx, result.To, delegator.Address())
+		s.Require().NoError(err)
+		s.Require().Equal(int64(50), stake.Int64())
+	}
+	s.Require().True(errors.Is(results[3].Err, ErrInsufficientStake))
+	s.Require().Nil(results[3].Tx)
+
+	_, err = s.StakingClient.DelegateBatch(s.ctx, delegator, delegations[:1], WithNonce(results[0].Tx.Nonce))
+	s.Require().Error(err)
+
+	balance, err := s.Backend.BalanceAt(s.ctx, delegator.Address(), nil)
+	s.Require().NoError(err)
+	half := new(big.Int).Div(balance, big.NewInt(2))
+	_, err = s.StakingClient.DelegateBatch(s.ctx, delegator, []Delegation{
+		{To: delegations[0].To, Amount: half},
+		{To: delegations[1].To, Amount: half},
+		{To: delegations[2].To},
+	})
+	s.Require().True(errors.Is(err, ErrInsufficientBalance))
+}
+
+func (s *ClientSuite) TestRedelegate() {
+	from, to := NewKeySigner(s.FundedKeys[0]), NewKeySigner(s.FundedKeys[1])
+	for _, signer := range []Signer{from, to} {
+		_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+		s.Require().NoError(err)
+	}
+	delegator := NewKeySigner(s.FundedKeys[2])
+	amount := big.NewInt(50)
+	_, err := s.StakingClient.Delegate(s.ctx, delegator, from.Address(), amount)
+	s.Require().NoError(err)
+
+	var stages []RedelegationStage
+	r := &Redelegation{From: from.Address(), To: to.Address(), Amount: amount}
+	// transcoder is not bonded, withdrawal completes immediatly
+	s.Require().NoError(s.StakingClient.Redelegate(s.ctx, delegator, r, func(progress Redelegation) {
+		stages = append(stages, progress.Stage)
+	}))
+	s.Require().Equal([]RedelegationStage{
+		RedelegationWithdrawalRequested,
+		RedelegationWithdrawn,
+		RedelegationDelegating,
+		RedelegationDone,
+	}, stages)
+
+	stake, err := s.StakingClient.GetDelegatorStake(s.ctx, from.Address(), delegator.Address())
+	s.Require().NoError(err)
+	s.Require().Zero(stake.Int64())
+	stake, err = s.StakingClient.GetDelegatorStake(s.ctx, to.Address(), delegator.Address())
+	s.Require().NoError(err)
+	s.Require().Equal(amount.Int64(), stake.Int64())
+}
+
+func (s *ClientSuite) TestRedelegateResume() {
+	from, to := NewKeySigner(s.FundedKeys[0]), NewKeySigner(s.FundedKeys[1])
+	for _, signer := range []Signer{from, to} {
+		_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+		s.Require().NoError(err)
+	}
+	amount := big.NewInt(50)
+	for i, interrupted := range []RedelegationStage{RedelegationWithdrawalRequested, RedelegationDelegating} {
+		delegator := NewKeySigner(s.FundedKeys[2+i])
+		_, err := s.StakingClient.Delegate(s.ctx, delegator, from.Address(), amount)
+		s.Require().NoError(err)
+
+		// process is interrupted right after the stage was persisted
+		var persisted []byte
+		ctx, cancel := context.WithCancel(s.ctx)
+		r := &Redelegation{From: from.Address(), To: to.Address(), Amount: amount}
+		err = s.StakingClient.Redelegate(ctx, delegator, r, func(progress Redelegation) {
+			var err error
+			persisted, err = json.Marshal(progress)
+			s.Require().NoError(err)
+			if progress.Stage == interrupted {
+				cancel()
+			}
+		})
+		cancel()
+		s.Require().True(errors.Is(err, context.Canceled))
+
+		restored := &Redelegation{}
+		s.Require().NoError(json.Unmarshal(persisted, restored))
+		s.Require().Equal(interrupted, restored.Stage)
+		restarted, err := NewClient(s.Backend, s.ContractAddress, WithChainID(simulatedChainID))
+		s.Require().NoError(err)
+		s.Require().NoError(restarted.Redelegate(s.ctx, delegator, restored, nil))
+		s.Require().Equal(RedelegationDone, restored.Stage)
+
+		stake, err := s.StakingClient.GetDelegatorStake(s.ctx, from.Address(), delegator.Address())
+		s.Require().NoError(err)
+		s.Require().Zero(stake.Int64())
+		stake, err = s.StakingClient.GetDelegatorStake(s.ctx, to.Address(), delegator.Address())
+		s.Require().NoError(err)
+		s.Require().Equal(amount.Int64(), stake.Int64())
+	}
+}
+
+func (s *ClientSuite) TestRedelegateInvalid() {
+	from := NewKeySigner(s.FundedKeys[0])
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, from, 10)
+	s.Require().NoError(err)
+	delegator := NewKeySigner(s.FundedKeys[2])
+	amount := big.NewInt(50)
+	_, err = s.StakingClient.Delegate(s.ctx, delegator, from.Address(), amount)
+	s.Require().NoError(err)
+
+	unregistered := NewKeySigner(s.FundedKeys[1]).Address()
+	r := &Redelegation{From: from.Address(), To: unregistered, Amount: amount}
+	err = s.StakingClient.Redelegate(s.ctx, delegator, r, nil)
+	s.Require().True(errors.Is(err, ErrTranscoderNotRegistered))
+	s.Require().Equal(RedelegationStarted, r.Stage)
+	stake, err := s.StakingClient.GetDelegatorStake(s.ctx, from.Address(), delegator.Address())
+	s.Require().NoError(err)
+	s.Require().Equal(amount.Int64(), stake.Int64())
+
+	withdrawn := &Redelegation{From: from.Address(), To: from.Address(), Amount: amount, Stage: RedelegationWithdrawn}
+	s.Require().Error(s.StakingClient.Redelegate(s.ctx, delegator, withdrawn, nil))
+}
+
+func (s *ClientSuite) TestWithdrawalEvents() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+	amount := big.NewInt(50)
+	_, err = s.StakingClient.Delegate(s.ctx, signer, signer.Address(), amount)
+	s.Require().NoError(err)
+
+	info, err := s.StakingClient.RequestWithdrawal(s.ctx, signer, signer.Address(), amount)
+	s.Require().NoError(err)
+	var names []string
+	for _, event := range info.Tx.Events {
+		s.Require().Equal(s.ContractAddress, event.Log.Address)
+		names = append(names, event.Name)
+	}
+	s.Require().Contains(names, "UnbondingRequested")
+	s.Require().Contains(names, "StakeWithdrawal")
+}
+
+func (s *ClientSuite) TestObserver() {
+	var (
+		mu     sync.Mutex
+		events []TxEvent
+	)
+	observer := ObserverFunc(func(event TxEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	})
+	client, err := NewClient(s.Backend, s.ContractAddress, WithChainID(simulatedChainID), WithObserver(observer))
+	s.Require().NoError(err)
+
+	signer := NewKeySigner(s.FundedKeys[0])
+	result, err := client.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	var observed []TxEventType
+	for _, event := range events {
+		observed = append(observed, event.Type)
+		s.Require().Equal(TxRegisterTranscoder, event.Kind)
+		s.Require().Equal(signer.Address(), event.From)
+		if event.Type != TxBuilt {
+			s.Require().Equal(result.Hash, event.Hash)
+		}
+	}
+	s.Require().Equal([]TxEventType{TxBuilt, TxSigned, TxBroadcasted, TxMined, TxConfirmed}, observed)
+	s.Require().NotNil(events[len(events)-1].Receipt)
+}
+
+func (s *ClientSuite) TestReadAtBlock() {
+	signer := NewKeySigner(s.FundedKeys[0])
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+	s.Require().NoError(err)
+
+	resume := s.pauseMining()
+	defer resume()
+	header, err := s.Backend.HeaderByNumber(s.ctx, nil)
+	s.Require().NoError(err)
+	at := AtBlock(header.Number)
+	transcoder, err := s.StakingClient.GetTranscoder(s.ctx, signer.Address(), at)
+	s.Require().NoError(err)
+	all, err := s.StakingClient.GetAllTranscoders(s.ctx, at)
+	s.Require().NoError(err)
+	s.Require().Equal([]Transcoder{transcoder}, all)
+	timestamp, err := s.StakingClient.HeadTimestamp(s.ctx, at)
+	s.Require().NoError(err)
+	s.Require().Equal(header.Time, timestamp)
+}
+
+func (s *ClientSuite) TestSnapshot() {
+	var delegated int64
+	for i, key := range s.FundedKeys[:3] {
+		signer := NewKeySigner(key)
+		_, err := s.StakingClient.RegisterTranscoder(s.ctx, signer, 10)
+		s.Require().NoError(err)
+		amount := big.NewInt(int64(50 * (i + 1)))
+		_, err = s.StakingClient.Delegate(s.ctx, NewKeySigner(s.FundedKeys[3]), signer.Address(), amount)
+		s.Require().NoError(err)
+		delegated += amount.Int64()
+	}
+
+	resume := s.pauseMining()
+	defer resume()
+	snapshot, err := s.StakingClient.Snapshot(s.ctx, nil)
+	s.Require().NoError(err)
+	header, err := s.Backend.HeaderByNumber(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Require().Equal(header.Hash(), snapshot.BlockHash)
+	s.Require().Equal(header.Time, snapshot.Timestamp)
+	s.Require().Len(snapshot.Transcoders, 3)
+	s.Require().Equal(delegated, snapshot.DelegatedStake.Int64())
+	s.Require().Equal(snapshot.TotalStake.Int64(), snapshot.SelfStake.Int64()+snapshot.DelegatedStake.Int64())
+
+	min, err := s.StakingClient.GetMinDelegation(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(min, snapshot.MinDelegation)
+}
+
+func (s *ClientSuite) TestConcurrentFetch() {
+	for _, key := range s.FundedKeys[:5] {
+		_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(key), 10)
+		s.Require().NoError(err)
+	}
+	client, err := NewClient(s.Backend, s.ContractAddress, WithChainID(simulatedChainID), WithFetchConcurrency(3))
+	s.Require().NoError(err)
+
+	resume := s.pauseMining()
+	defer resume()
+	sequential, err := s.StakingClient.GetAllTranscoders(s.ctx)
+	s.Require().NoError(err)
+	concurrent, err := client.GetAllTranscoders(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Len(concurrent, 5)
+	s.Require().Equal(sequential, concurrent)
+}
+
+// simulatedBatchCaller executes batched eth_call requests one by one on the latest state of the simulated backend.
+type simulatedBatchCaller struct {
+	backend *backends.SimulatedBackend
+	batches int
+	fail    bool
+}
+
+func (b *simulatedBatchCaller) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	b.batches++
+	if b.fail {
+		return errors.New("batch requests are not supported")
+	}
+	for i := range batch {
+		call := batch[i].Args[0].(map[string]interface{})
+		to := call["to"].(common.Address)
+		out, err := b.backend.CallContract(ctx, ethereum.CallMsg{To: &to, Data: call["data"].(hexutil.Bytes)}, nil)
+		if err != nil {
+			batch[i].Error = err
+			continue
+		}
+		*batch[i].Result.(*hexutil.Bytes) = out
+	}
+	return nil
+}
+
+func (s *ClientSuite) TestBatchCaller() {
+	for _, key := range s.FundedKeys[:3] {
+		_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(key), 10)
+		s.Require().NoError(err)
+	}
+	caller := &simulatedBatchCaller{backend: s.Backend}
+	client, err := NewClient(s.Backend, s.ContractAddress, WithChainID(simulatedChainID), WithBatchCaller(caller))
+	s.Require().NoError(err)
+
+	resume := s.pauseMining()
+	defer resume()
+	expected, err := s.StakingClient.GetAllTranscoders(s.ctx)
+	s.Require().NoError(err)
+	batched, err := client.GetAllTranscoders(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(expected, batched)
+	s.Require().Equal(2, caller.batches)
+
+	caller.fail = true
+	fallback, err := client.GetAllTranscoders(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(expected, fallback)
+}
+
+// countingBackend counts contract calls executed by the simulated backend.
+type countingBackend struct {
+	*backends.SimulatedBackend
+	calls int
+}
+
+func (b *countingBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	b.calls++
+	return b.SimulatedBackend.CallContract(ctx, msg, block)
+}
+
+func (s *ClientSuite) TestCachedClient() {
+	_, err := s.StakingClient.RegisterTranscoder(s.ctx, NewKeySigner(s.FundedKeys[0]), 10)
+	s.Require().NoError(err)
+	backend := &countingBackend{SimulatedBackend: s.Backend}
+	client, err := NewClient(backend, s.ContractAddress, WithChainID(simulatedChainID))
+	s.Require().NoError(err)
+	cached := NewCachedClient(client, CacheConfig{ParamsTTL: time.Hour})
+
+	resume := s.pauseMining()
+	defer resume()
+	tcrs, err := cached.GetBondedTranscoders(s.ctx)
+	s.Require().NoError(err)
+	period, err := cached.GetUnbondingPeriod(s.ctx)
+	s.Require().NoError(err)
+	calls := backend.calls
+
+	again, err := cached.GetBondedTranscoders(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(tcrs, again)
+	_, err = cached.GetUnbondingPeriod(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(calls, backend.calls)
+
+	s.Backend.Commit()
+	_, err = cached.GetBondedTranscoders(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Greater(backend.calls, calls)
+
+	calls = backend.calls
+	afterHead, err := cached.GetUnbondingPeriod(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(period, afterHead)
+	s.Require().Equal(calls, backend.calls)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -48,8 +49,17 @@ func main() {
 	must(err)
 	key, err := crypto.DecryptKeyFile(c.Key, c.Password)
 	must(err)
+	chainID, err := client.ChainID(ctx)
+	must(err)
 	opts := bind.NewKeyedTransactor(key.PrivateKey)
 	opts.Context = ctx
+	// keyed transactor signs without replay protection
+	opts.Signer = func(_ types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != opts.From {
+			return nil, errors.New("not authorized to sign this account")
+		}
+		return types.SignTx(tx, types.NewEIP155Signer(chainID), key.PrivateKey)
+	}
 	if c.UpdateApproval {
 		tx, err := contract.SetApprovalPeriod(opts, new(big.Int).SetUint64(uint64(c.ApprovalPeriod.Seconds())))
 		must(err)
@@ -0,0 +1,171 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrTransactionReverted raised when transaction was mined but has failed status.
+	ErrTransactionReverted = errors.New("transaction reverted")
+	// ErrInsufficientStake raised if delegated amount smaller than configured min delegation.
+	ErrInsufficientStake = errors.New("insufficient stake")
+	// ErrAlreadyRegistered raised if transcoder was already registered.
+	ErrAlreadyRegistered = errors.New("already registered")
+	// ErrNoPendingWithdrawals when there are not available withdrawals to complete.
+	ErrNoPendingWithdrawals = errors.New("no pending withdrawals aviable")
+	// ErrTranscoderNotRegistered is raised when transcoder wasn't registered
+	ErrTranscoderNotRegistered = errors.New("not registered")
+	// ErrTransactionCancelled raised when cancellation of the transaction was mined instead of it.
+	ErrTransactionCancelled = errors.New("transaction cancelled")
+	// ErrTransactionNotPending raised when transaction can't be replaced because it is not pending anymore.
+	ErrTransactionNotPending = errors.New("transaction is not pending")
+	// ErrTransactionDropped raised when block with mined transaction was reorganized and transaction
+	// is not known to the node anymore.
+	ErrTransactionDropped = errors.New("transaction dropped by reorg")
+	// ErrInsufficientBalance raised when account balance can't cover transaction value and fee.
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrOverWithdrawal raised when requested withdrawal is larger than delegated stake.
+	ErrOverWithdrawal = errors.New("withdrawal exceeds delegated stake")
+	// ErrGasEstimationFailed raised when node failed to estimate gas for transaction.
+	ErrGasEstimationFailed = errors.New("gas estimation failed")
+	// ErrChainIDUnknown raised when chain id is neither configured with WithChainID nor reported by the node.
+	ErrChainIDUnknown = errors.New("chain id unknown")
+	// ErrChainIDMismatch raised when configured chain id differs from the one reported by the node, or
+	// when transaction is signed for another chain.
+	ErrChainIDMismatch = errors.New("chain id mismatch")
+)
+
+// TranscoderError annotates an error with transcoder address.
+type TranscoderError struct {
+	Transcoder common.Address
+	Err        error
+}
+
+func (e *TranscoderError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Transcoder.String())
+}
+
+func (e *TranscoderError) Unwrap() error {
+	return e.Err
+}
+
+// InsufficientStakeError is raised when amount is lower than required.
+type InsufficientStakeError struct {
+	Transcoder common.Address
+	Amount     *big.Int
+	Required   *big.Int
+}
+
+func (e *InsufficientStakeError) Error() string {
+	return fmt.Sprintf("%v: amount %v is smaller than required delegation %v",
+		ErrInsufficientStake, e.Amount, e.Required)
+}
+
+func (e *InsufficientStakeError) Is(target error) bool {
+	return target == ErrInsufficientStake
+}
+
+// OverWithdrawalError is raised when delegator requests more than it has delegated to transcoder.
+type OverWithdrawalError struct {
+	Transcoder common.Address
+	Delegator  common.Address
+	Requested  *big.Int
+	Delegated  *big.Int
+}
+
+func (e *OverWithdrawalError) Error() string {
+	return fmt.Sprintf("%v: delegated amount %v is less than requested %v. delegator 0x%x, transcoder 0x%x",
+		ErrOverWithdrawal, e.Delegated, e.Requested, e.Delegator, e.Transcoder)
+}
+
+func (e *OverWithdrawalError) Is(target error) bool {
+	return target == ErrOverWithdrawal
+}
+
+// InsufficientBalanceError is raised when account balance can't cover value and fee of the transaction.
+// If balance doesn't cover value fee is estimated for the call without value, or with the gas limit set
+// by WithGasLimit. Required doesn't include fee only if such estimation fails too.
+type InsufficientBalanceError struct {
+	Account  common.Address
+	Balance  *big.Int
+	Required *big.Int
+}
+
+// Shortfall is an amount that account is missing to cover transaction.
+func (e *InsufficientBalanceError) Shortfall() *big.Int {
+	return new(big.Int).Sub(e.Required, e.Balance)
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("%v: 0x%x has %v, required %v, missing %v",
+		ErrInsufficientBalance, e.Account, e.Balance, e.Required, e.Shortfall())
+}
+
+func (e *InsufficientBalanceError) Is(target error) bool {
+	return target == ErrInsufficientBalance
+}
+
+// GasEstimationError is raised when node fails to estimate gas for transaction. Err is *RevertError
+// if estimation failed because transaction reverts.
+type GasEstimationError struct {
+	Kind TxKind
+	From common.Address
+	Err  error
+}
+
+func (e *GasEstimationError) Error() string {
+	return fmt.Sprintf("%v: %v from 0x%x: %v", ErrGasEstimationFailed, e.Kind, e.From, e.Err)
+}
+
+func (e *GasEstimationError) Is(target error) bool {
+	return target == ErrGasEstimationFailed
+}
+
+func (e *GasEstimationError) Unwrap() error {
+	return e.Err
+}
+
+// RevertError is returned when simulated transaction reverts. It matches ErrTransactionReverted
+// and unwraps into a typed error, e.g. *OverWithdrawalError, if contract state explains the revert.
+type RevertError struct {
+	Kind TxKind
+	// From is a sender of the transaction.
+	From common.Address
+	// Transcoder is a target of the delegation or withdrawal request. Zero for other transactions.
+	Transcoder common.Address
+	Reason     string
+
+	known error
+}
+
+func (e *RevertError) Error() string {
+	msg := fmt.Sprintf("%v from 0x%x", e.Kind, e.From)
+	if e.Transcoder != (common.Address{}) {
+		msg += fmt.Sprintf(" to 0x%x", e.Transcoder)
+	}
+	if e.Reason == "" {
+		return fmt.Sprintf("%s: %v", msg, ErrTransactionReverted)
+	}
+	return fmt.Sprintf("%s: %v: %s", msg, ErrTransactionReverted, e.Reason)
+}
+
+func (e *RevertError) Is(target error) bool {
+	return target == ErrTransactionReverted
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.known
+}
+
+func newRevertError(from common.Address, call Call, reason string) *RevertError {
+	return &RevertError{
+		Kind:       call.Kind,
+		From:       from,
+		Transcoder: call.target(),
+		Reason:     reason,
+	}
+}
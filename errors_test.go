@@ -0,0 +1,46 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuredErrors(t *testing.T) {
+	balance := fmt.Errorf("delegate: %w", &InsufficientBalanceError{
+		Account:  common.Address{1},
+		Balance:  big.NewInt(70),
+		Required: big.NewInt(100),
+	})
+	require.True(t, errors.Is(balance, ErrInsufficientBalance))
+	var balanceErr *InsufficientBalanceError
+	require.True(t, errors.As(balance, &balanceErr))
+	require.Equal(t, int64(30), balanceErr.Shortfall().Int64())
+
+	revert := newRevertError(common.Address{1}, RequestWithdrawalCall(common.Address{3}, big.NewInt(10)), "")
+	revert.known = &OverWithdrawalError{Transcoder: common.Address{3}, Delegator: common.Address{1},
+		Requested: big.NewInt(10), Delegated: big.NewInt(5)}
+	estimation := &GasEstimationError{Kind: TxRequestWithdrawal, Err: revert}
+	require.True(t, errors.Is(estimation, ErrGasEstimationFailed))
+	require.True(t, errors.Is(estimation, ErrTransactionReverted))
+	require.True(t, errors.Is(estimation, ErrOverWithdrawal))
+	var over *OverWithdrawalError
+	require.True(t, errors.As(estimation, &over))
+	require.Equal(t, int64(5), over.Delegated.Int64())
+	var reverted *RevertError
+	require.True(t, errors.As(estimation, &reverted))
+	require.Equal(t, common.Address{1}, reverted.From)
+	require.Equal(t, common.Address{3}, reverted.Transcoder)
+
+	unknown := newRevertError(common.Address{1}, DelegateCall(common.Address{3}, big.NewInt(1)), "insufficient balance")
+	require.True(t, errors.Is(unknown, ErrTransactionReverted))
+	require.False(t, errors.Is(unknown, ErrInsufficientBalance))
+	require.Nil(t, errors.Unwrap(unknown))
+
+	notRegistered := &TranscoderError{Transcoder: common.Address{2}, Err: ErrTranscoderNotRegistered}
+	require.True(t, errors.Is(notRegistered, ErrTranscoderNotRegistered))
+}
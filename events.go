@@ -0,0 +1,86 @@
+package staking
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	eventUnbondingRequested = "UnbondingRequested"
+	eventStakeWithdrawal    = "StakeWithdrawal"
+)
+
+// Event is a staking contract event decoded from the transaction receipt.
+type Event struct {
+	Name string
+	// Fields are decoded event arguments by name, both indexed and not indexed.
+	Fields map[string]interface{}
+	Log    types.Log
+}
+
+// isEvent returns true if log was emitted by the staking contract and has signature of the event.
+func (c *Client) isEvent(log *types.Log, name string) bool {
+	event, exist := c.abi.Events[name]
+	return exist && log.Address == c.address && len(log.Topics) > 0 && log.Topics[0] == event.ID()
+}
+
+// parseEvents decodes all logs emitted by the staking contract. Logs of other contracts and events
+// that are not known to the contract abi are skipped.
+func (c *Client) parseEvents(receipt *types.Receipt) ([]Event, error) {
+	var events []Event
+	for _, log := range receipt.Logs {
+		if log.Address != c.address || len(log.Topics) == 0 {
+			continue
+		}
+		event, err := c.abi.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+		fields := map[string]interface{}{}
+		if err := c.bound.UnpackLogIntoMap(fields, event.Name, *log); err != nil {
+			return nil, err
+		}
+		events = append(events, Event{Name: event.Name, Fields: fields, Log: *log})
+	}
+	return events, nil
+}
+
+func (c *Client) parseRequestWithdrawal(receipt *types.Receipt) (info WithdrawalInfo, err error) {
+	for _, log := range receipt.Logs {
+		switch {
+		case c.isEvent(log, eventUnbondingRequested):
+			unbonding, err := c.contract.ParseUnbondingRequested(*log)
+			if err != nil {
+				return info, err
+			}
+			if info.Amount == nil {
+				info.ReadinessTimestamp = unbonding.Readiness.Uint64()
+			}
+		case c.isEvent(log, eventStakeWithdrawal):
+			withdraw, err := c.contract.ParseStakeWithdrawal(*log)
+			if err != nil {
+				return info, err
+			}
+			info.Amount = withdraw.Amount
+			info.ReadinessTimestamp = 0 // set timestamp to 0 cause request was already completed.
+		}
+	}
+	return info, nil
+}
+
+func (c *Client) parseCompleteWithdrawals(receipt *types.Receipt) (info WithdrawalInfo, err error) {
+	amount := new(big.Int)
+	for _, log := range receipt.Logs {
+		if !c.isEvent(log, eventStakeWithdrawal) {
+			continue
+		}
+		withdraw, err := c.contract.ParseStakeWithdrawal(*log)
+		if err != nil {
+			return info, err
+		}
+		amount = amount.Add(amount, withdraw.Amount)
+	}
+	info.Amount = amount
+	return info, nil
+}
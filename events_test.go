@@ -0,0 +1,35 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIgnoresUnrelatedLogs(t *testing.T) {
+	client, err := NewClient(nil, common.Address{1})
+	require.NoError(t, err)
+	withdrawal := client.abi.Events[eventStakeWithdrawal].ID()
+	receipt := &types.Receipt{Logs: []*types.Log{
+		// same event emitted by another contract
+		{Address: common.Address{2}, Topics: []common.Hash{withdrawal}, Data: []byte{1}},
+		// unknown event of the staking contract
+		{Address: common.Address{1}, Topics: []common.Hash{{0xff}}},
+		{Address: common.Address{1}},
+	}}
+
+	events, err := client.parseEvents(receipt)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	info, err := client.parseCompleteWithdrawals(receipt)
+	require.NoError(t, err)
+	require.Zero(t, info.Amount.Int64())
+
+	info, err = client.parseRequestWithdrawal(receipt)
+	require.NoError(t, err)
+	require.Nil(t, info.Amount)
+	require.Zero(t, info.ReadinessTimestamp)
+}
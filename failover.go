@@ -0,0 +1,350 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Selection decides order in which healthy backends are used by FailoverBackend.
+type Selection uint8
+
+const (
+	// SelectPriority uses backends in the order they were passed to NewFailoverBackend.
+	SelectPriority Selection = iota
+	// SelectRoundRobin rotates healthy backends for every request.
+	SelectRoundRobin
+)
+
+// FailoverConfig configures FailoverBackend.
+type FailoverConfig struct {
+	Selection Selection
+	// CheckInterval is an interval of health checks performed by Run. Default is 15s.
+	CheckInterval time.Duration
+	// CheckTimeout limits every health check request. Default is 5s.
+	CheckTimeout time.Duration
+	// MaxHeadLag is a number of blocks that backend may lag behind the highest head of all backends
+	// and still be healthy. Zero disables head lag detection.
+	MaxHeadLag uint64
+	// RecoverAfter is a period after which backend that failed a request is used as healthy again.
+	// It is marked healthy if the next request succeeds. Default is CheckInterval.
+	RecoverAfter time.Duration
+	// Retryable classifies errors that cause failover, normally the same as in RetryPolicy.
+	// DefaultRetryable is used if nil.
+	Retryable func(error) bool
+}
+
+// BackendStatus is a result of the latest health check of the backend.
+type BackendStatus struct {
+	Healthy bool
+	Head    uint64
+	// Err is an error of the latest request or health check. Nil if backend is healthy.
+	Err error
+}
+
+// FailoverBackend is an ETHBackend that sends every request to a healthy backend and fails over to the next one
+// if request fails with transport error, as classified by FailoverConfig.Retryable. Unhealthy backends are used
+// only if all backends are unhealthy. Requests pinned to a block also fail over if backend doesn't have the block,
+// e.g. lags one block behind the backend that reported the head. Transactions are broadcasted to a single
+// backend without failover.
+type FailoverBackend struct {
+	backends []ETHBackend
+	cfg      FailoverConfig
+
+	mu     sync.Mutex
+	status []BackendStatus
+	// recoverAt is set for backends that failed a request.
+	recoverAt []time.Time
+	next      int
+}
+
+// NewFailoverBackend returns FailoverBackend for backends. All backends are considered healthy until
+// checked with Check or Run.
+func NewFailoverBackend(cfg FailoverConfig, backends ...ETHBackend) (*FailoverBackend, error) {
+	if len(backends) == 0 {
+		return nil, errors.New("failover requires at least one backend")
+	}
+	if cfg.CheckInterval == 0 {
+		cfg.CheckInterval = 15 * time.Second
+	}
+	if cfg.CheckTimeout == 0 {
+		cfg.CheckTimeout = 5 * time.Second
+	}
+	if cfg.RecoverAfter == 0 {
+		cfg.RecoverAfter = cfg.CheckInterval
+	}
+	if cfg.Retryable == nil {
+		cfg.Retryable = DefaultRetryable
+	}
+	status := make([]BackendStatus, len(backends))
+	for i := range status {
+		status[i].Healthy = true
+	}
+	return &FailoverBackend{backends: backends, cfg: cfg, status: status, recoverAt: make([]time.Time, len(backends))}, nil
+}
+
+// Status returns statuses of backends in the order they were passed to NewFailoverBackend.
+func (b *FailoverBackend) Status() []BackendStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]BackendStatus(nil), b.status...)
+}
+
+// Run checks health of backends every CheckInterval until context is done.
+func (b *FailoverBackend) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		b.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check requests head from every backend. Backend is unhealthy if request failed or head lags more
+// than MaxHeadLag blocks behind the highest head.
+func (b *FailoverBackend) Check(ctx context.Context) {
+	status := make([]BackendStatus, len(b.backends))
+	var wg sync.WaitGroup
+	for i := range b.backends {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, b.cfg.CheckTimeout)
+			defer cancel()
+			header, err := b.backends[i].HeaderByNumber(ctx, nil)
+			if err != nil {
+				status[i].Err = err
+				return
+			}
+			status[i].Healthy = true
+			status[i].Head = header.Number.Uint64()
+		}(i)
+	}
+	wg.Wait()
+	var highest uint64
+	for i := range status {
+		if status[i].Healthy && status[i].Head > highest {
+			highest = status[i].Head
+		}
+	}
+	for i := range status {
+		if status[i].Healthy && b.cfg.MaxHeadLag != 0 && highest-status[i].Head > b.cfg.MaxHeadLag {
+			status[i].Healthy = false
+			status[i].Err = fmt.Errorf("head %d lags behind %d", status[i].Head, highest)
+		}
+	}
+	b.mu.Lock()
+	b.status = status
+	b.recoverAt = make([]time.Time, len(b.backends))
+	b.mu.Unlock()
+}
+
+// candidates returns indexes of backends in order they should be tried.
+func (b *FailoverBackend) candidates() []int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var (
+		healthy, unhealthy []int
+		now                = time.Now()
+	)
+	for i := range b.status {
+		recovered := !b.recoverAt[i].IsZero() && !now.Before(b.recoverAt[i])
+		if b.status[i].Healthy || recovered {
+			healthy = append(healthy, i)
+		} else {
+			unhealthy = append(unhealthy, i)
+		}
+	}
+	if b.cfg.Selection == SelectRoundRobin && len(healthy) > 0 {
+		start := b.next % len(healthy)
+		b.next++
+		healthy = append(healthy[start:], healthy[:start]...)
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (b *FailoverBackend) failed(i int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.status[i].Healthy = false
+	b.status[i].Err = err
+	b.recoverAt[i] = time.Now().Add(b.cfg.RecoverAfter)
+}
+
+// succeeded marks backend that failed a request healthy. Backends that are unhealthy by the health check
+// are recovered only by the next check.
+func (b *FailoverBackend) succeeded(i int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.recoverAt[i].IsZero() {
+		return
+	}
+	b.status[i].Healthy = true
+	b.status[i].Err = nil
+	b.recoverAt[i] = time.Time{}
+}
+
+func (b *FailoverBackend) do(request func(ETHBackend) error) error {
+	return b.doAt(nil, request)
+}
+
+// doAt sends request pinned to the block. Backend that doesn't have the block yet, e.g. because it lags behind
+// the backend that reported the head, is skipped without being marked unhealthy.
+func (b *FailoverBackend) doAt(block *big.Int, request func(ETHBackend) error) (err error) {
+	for _, i := range b.candidates() {
+		err = request(b.backends[i])
+		if block != nil && missingBlock(err) {
+			continue
+		}
+		if err == nil || !b.cfg.Retryable(err) {
+			b.succeeded(i)
+			return err
+		}
+		b.failed(i, err)
+	}
+	return err
+}
+
+// missingBlock is true if backend doesn't have the requested block or its state.
+func missingBlock(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "header not found") ||
+		strings.Contains(msg, "unknown block") ||
+		strings.Contains(msg, "missing trie node")
+}
+
+func (b *FailoverBackend) selected() ETHBackend {
+	return b.backends[b.candidates()[0]]
+}
+
+func (b *FailoverBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = b.doAt(blockNumber, func(backend ETHBackend) error {
+		code, err = backend.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (b *FailoverBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (out []byte, err error) {
+	err = b.doAt(blockNumber, func(backend ETHBackend) error {
+		out, err = backend.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return out, err
+}
+
+func (b *FailoverBackend) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = b.do(func(backend ETHBackend) error {
+		code, err = backend.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+func (b *FailoverBackend) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = b.do(func(backend ETHBackend) error {
+		nonce, err = backend.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+func (b *FailoverBackend) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = b.do(func(backend ETHBackend) error {
+		price, err = backend.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+func (b *FailoverBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = b.do(func(backend ETHBackend) error {
+		gas, err = backend.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction broadcasts transaction to the first healthy backend.
+func (b *FailoverBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return b.selected().SendTransaction(ctx, tx)
+}
+
+func (b *FailoverBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = b.do(func(backend ETHBackend) error {
+		logs, err = backend.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs subscribes with the first healthy backend. Subscription is not moved to another
+// backend if it fails.
+func (b *FailoverBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery,
+	ch chan<- types.Log) (ethereum.Subscription, error) {
+	return b.selected().SubscribeFilterLogs(ctx, query, ch)
+}
+
+func (b *FailoverBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (receipt *types.Receipt, err error) {
+	err = b.do(func(backend ETHBackend) error {
+		receipt, err = backend.TransactionReceipt(ctx, hash)
+		return err
+	})
+	return receipt, err
+}
+
+func (b *FailoverBackend) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = b.doAt(number, func(backend ETHBackend) error {
+		header, err = backend.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+func (b *FailoverBackend) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, pending bool, err error) {
+	err = b.do(func(backend ETHBackend) error {
+		tx, pending, err = backend.TransactionByHash(ctx, hash)
+		return err
+	})
+	return tx, pending, err
+}
+
+func (b *FailoverBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = b.doAt(blockNumber, func(backend ETHBackend) error {
+		balance, err = backend.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+// ChainID returns nil if backends don't report chain id.
+func (b *FailoverBackend) ChainID(ctx context.Context) (chainID *big.Int, err error) {
+	err = b.do(func(backend ETHBackend) error {
+		reader, ok := backend.(chainIDReader)
+		if !ok {
+			chainID = nil
+			return nil
+		}
+		chainID, err = reader.ChainID(ctx)
+		return err
+	})
+	return chainID, err
+}
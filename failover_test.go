@@ -0,0 +1,141 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type headBackend struct {
+	ETHBackend
+	head uint64
+	err  error
+	used int
+}
+
+func (b *headBackend) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	b.used++
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(b.head)}, nil
+}
+
+func TestFailoverBackend(t *testing.T) {
+	primary := &headBackend{head: 10, err: errors.New("connection refused")}
+	secondary := &headBackend{head: 10}
+	backend, err := NewFailoverBackend(FailoverConfig{}, primary, secondary)
+	require.NoError(t, err)
+
+	header, err := backend.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), header.Number.Uint64())
+	status := backend.Status()
+	require.False(t, status[0].Healthy)
+	require.True(t, status[1].Healthy)
+
+	// unhealthy backend is not used while there is a healthy one
+	_, err = backend.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, primary.used)
+}
+
+func TestFailoverHeadLag(t *testing.T) {
+	lagging := &headBackend{head: 5}
+	synced := &headBackend{head: 10}
+	backend, err := NewFailoverBackend(FailoverConfig{MaxHeadLag: 2}, lagging, synced)
+	require.NoError(t, err)
+
+	backend.Check(context.Background())
+	status := backend.Status()
+	require.False(t, status[0].Healthy)
+	require.True(t, status[1].Healthy)
+	require.Equal(t, uint64(10), status[1].Head)
+
+	lagging.head = 9
+	backend.Check(context.Background())
+	require.True(t, backend.Status()[0].Healthy)
+}
+
+func TestFailoverRoundRobin(t *testing.T) {
+	first, second := &headBackend{}, &headBackend{}
+	backend, err := NewFailoverBackend(FailoverConfig{Selection: SelectRoundRobin}, first, second)
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		_, err := backend.HeaderByNumber(context.Background(), nil)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, first.used)
+	require.Equal(t, 2, second.used)
+}
+
+func TestFailoverRecover(t *testing.T) {
+	primary := &headBackend{head: 10, err: errors.New("connection reset by peer")}
+	secondary := &headBackend{head: 10}
+	backend, err := NewFailoverBackend(FailoverConfig{RecoverAfter: time.Millisecond}, primary, secondary)
+	require.NoError(t, err)
+
+	_, err = backend.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	require.False(t, backend.Status()[0].Healthy)
+
+	primary.err = nil
+	time.Sleep(5 * time.Millisecond)
+	_, err = backend.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, primary.used)
+	require.Equal(t, 1, secondary.used)
+	require.True(t, backend.Status()[0].Healthy)
+}
+
+func TestFailoverRetryable(t *testing.T) {
+	primary := &headBackend{err: errors.New("connection reset by peer")}
+	secondary := &headBackend{}
+	backend, err := NewFailoverBackend(FailoverConfig{Retryable: func(error) bool { return false }}, primary, secondary)
+	require.NoError(t, err)
+
+	_, err = backend.HeaderByNumber(context.Background(), nil)
+	require.Error(t, err)
+	require.Equal(t, 0, secondary.used)
+	require.True(t, backend.Status()[0].Healthy)
+}
+
+// laggingBackend answers pinned requests for blocks after its head the way a node does.
+type laggingBackend struct {
+	ETHBackend
+	head  int64
+	calls int
+}
+
+func (b *laggingBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	b.calls++
+	if block != nil && block.Int64() > b.head {
+		return nil, nodeError("header not found")
+	}
+	return []byte{1}, nil
+}
+
+func TestFailoverMissingBlock(t *testing.T) {
+	lagging, synced := &laggingBackend{head: 10}, &laggingBackend{head: 11}
+	backend, err := NewFailoverBackend(FailoverConfig{Selection: SelectRoundRobin}, lagging, synced)
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		out, err := backend.CallContract(context.Background(), ethereum.CallMsg{}, big.NewInt(11))
+		require.NoError(t, err)
+		require.Equal(t, []byte{1}, out)
+	}
+	require.Equal(t, 2, lagging.calls)
+	require.Equal(t, 4, synced.calls)
+	for _, status := range backend.Status() {
+		require.True(t, status.Healthy)
+	}
+
+	_, err = backend.CallContract(context.Background(), ethereum.CallMsg{}, big.NewInt(12))
+	require.Error(t, err)
+}
@@ -0,0 +1,114 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+)
+
+// fetchTranscoders returns all transcoders at the same block. Reads are batched if client is configured
+// with WithMulticall or WithBatchCaller. Otherwise, if fetch concurrency is higher than one, transcoders
+// are fetched by a pool of workers. Results are in index order, error of the lowest failed index is returned
+// and transcoders after it are not fetched.
+func (c *Client) fetchTranscoders(ctx context.Context, opts []ReadOption) (tcrs []Transcoder, err error) {
+	if c.multicall != nil || c.batchCaller != nil {
+		opts, err = c.pinHead(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		tcrs, err = c.batchTranscoders(ctx, opts)
+		// batching is an optimization, transcoders are fetched call by call if it failed.
+		var terr *TranscoderError
+		if err == nil || ctx.Err() != nil || errors.As(err, &terr) {
+			return tcrs, err
+		}
+	}
+	if c.fetchConcurrency <= 1 {
+		iter, err := c.TranscoderIterator(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for iter.Next(ctx) {
+			tcrs = append(tcrs, iter.Current())
+		}
+		if iter.Error() != nil {
+			return nil, iter.Error()
+		}
+		return tcrs, nil
+	}
+	opts, err = c.pinHead(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	count, err := c.TranscodersCount(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if count.Sign() == 0 {
+		return nil, nil
+	}
+	tcrs = make([]Transcoder, count.Int64())
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+		// failed is the lowest failed index, len(tcrs) if none failed.
+		failed  = len(tcrs)
+		errs    = make([]error, len(tcrs))
+		indexes = make(chan int)
+		workers = c.fetchConcurrency
+	)
+	skip := func(i int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return i > failed
+	}
+	if workers > len(tcrs) {
+		workers = len(tcrs)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if skip(i) {
+					continue
+				}
+				tcr, err := c.GetTranscoderAt(ctx, big.NewInt(int64(i)), opts...)
+				if err != nil {
+					mu.Lock()
+					errs[i] = err
+					if i < failed {
+						failed = i
+					}
+					mu.Unlock()
+					continue
+				}
+				tcrs[i] = tcr
+			}
+		}()
+	}
+feed:
+	for i := range tcrs {
+		if skip(i) {
+			break
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return tcrs, nil
+}
@@ -0,0 +1,41 @@
+package staking
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/videocoin/go-contracts/bindings/staking"
+)
+
+func TestFetchLowestError(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(staking.StakingManagerABI))
+	require.NoError(t, err)
+	transcoders := make([]Transcoder, 8)
+	for i := range transcoders {
+		transcoders[i] = Transcoder{
+			Address:               common.BytesToAddress([]byte{byte(i + 1)}),
+			TotalStake:            big.NewInt(100),
+			SelfStake:             big.NewInt(10),
+			DelegatedStake:        big.NewInt(90),
+			Capacity:              big.NewInt(1000),
+			Timestamp:             1000,
+			EffectiveMinSelfStake: big.NewInt(5),
+		}
+	}
+	// second transcoder fails after the fifth one
+	backend := &stakingBackend{abi: parsed, transcoders: transcoders, failures: map[common.Address]time.Duration{
+		transcoders[1].Address: 50 * time.Millisecond,
+		transcoders[4].Address: 0,
+	}}
+	client, err := NewClient(backend, common.Address{1}, WithFetchConcurrency(4))
+	require.NoError(t, err)
+	_, err = client.GetAllTranscoders(context.Background())
+	require.EqualError(t, err, fmt.Sprintf("transcoder 0x%x is not available", transcoders[1].Address))
+}
@@ -0,0 +1,134 @@
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GasPriceStrategy decides gas price of the staking transaction.
+type GasPriceStrategy interface {
+	GasPrice(ctx context.Context, backend bind.ContractTransactor) (*big.Int, error)
+}
+
+// GasPriceFunc is an adapter to use ordinary function as GasPriceStrategy.
+type GasPriceFunc func(ctx context.Context, backend bind.ContractTransactor) (*big.Int, error)
+
+func (f GasPriceFunc) GasPrice(ctx context.Context, backend bind.ContractTransactor) (*big.Int, error) {
+	return f(ctx, backend)
+}
+
+// gasPrice returns gas price according to the strategy of the transaction.
+func (c *Client) gasPrice(ctx context.Context, cfg txConfig) (*big.Int, error) {
+	if cfg.gasPrice == nil {
+		return c.client.SuggestGasPrice(ctx)
+	}
+	return cfg.gasPrice.GasPrice(ctx, c.client)
+}
+
+// fees returns gas limit and gas price for the call. Gas is estimated unless it is set with WithGasLimit.
+func (c *Client) fees(ctx context.Context, from common.Address, call Call, cfg txConfig) (gas uint64, gasPrice *big.Int, err error) {
+	gas = cfg.gasLimit
+	if gas == 0 {
+		gas, err = c.estimateGas(ctx, from, call)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	gasPrice, err = c.gasPrice(ctx, cfg)
+	if err != nil {
+		return 0, nil, err
+	}
+	return gas, gasPrice, nil
+}
+
+func (c *Client) estimate(ctx context.Context, from common.Address, call Call, opts []TxOption) (FeeEstimate, error) {
+	cfg := c.newTxConfig(opts)
+	gas, gasPrice, err := c.fees(ctx, from, call, cfg)
+	if err != nil {
+		return FeeEstimate{}, err
+	}
+	return FeeEstimate{
+		Gas:      gas,
+		GasPrice: gasPrice,
+		Fee:      new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas)),
+	}, nil
+}
+
+// EstimateDelegate returns cost of the delegation of the amount to the transcoder. Gas price is decided
+// by the same strategy as for transaction with the same options.
+func (c *Client) EstimateDelegate(ctx context.Context, delegator, to common.Address, amount *big.Int,
+	opts ...TxOption) (FeeEstimate, error) {
+	if err := c.checkDelegate(ctx, to, amount); err != nil {
+		return FeeEstimate{}, err
+	}
+	return c.estimate(ctx, delegator, DelegateCall(to, amount), opts)
+}
+
+// EstimateRegisterTranscoder returns cost of the transcoder registration.
+func (c *Client) EstimateRegisterTranscoder(ctx context.Context, transcoder common.Address, rewardRate uint64,
+	opts ...TxOption) (FeeEstimate, error) {
+	if err := c.checkRegisterTranscoder(ctx, transcoder); err != nil {
+		return FeeEstimate{}, err
+	}
+	return c.estimate(ctx, transcoder, RegisterTranscoderCall(rewardRate), opts)
+}
+
+// EstimateRequestWithdrawal returns cost of the unbonding request of the amount delegated to the transcoder.
+func (c *Client) EstimateRequestWithdrawal(ctx context.Context, delegator, from common.Address, amount *big.Int,
+	opts ...TxOption) (FeeEstimate, error) {
+	if err := c.checkRequestWithdrawal(ctx, delegator, from, amount); err != nil {
+		return FeeEstimate{}, err
+	}
+	return c.estimate(ctx, delegator, RequestWithdrawalCall(from, amount), opts)
+}
+
+// EstimateCompleteWithdrawals returns cost of the completion of all pending withdrawals.
+func (c *Client) EstimateCompleteWithdrawals(ctx context.Context, delegator common.Address,
+	opts ...TxOption) (FeeEstimate, error) {
+	if err := c.checkCompleteWithdrawals(ctx, delegator); err != nil {
+		return FeeEstimate{}, err
+	}
+	return c.estimate(ctx, delegator, CompleteWithdrawalsCall(), opts)
+}
+
+// FixedGasPrice always uses the same gas price.
+func FixedGasPrice(price *big.Int) GasPriceStrategy {
+	price = new(big.Int).Set(price)
+	return GasPriceFunc(func(context.Context, bind.ContractTransactor) (*big.Int, error) {
+		return new(big.Int).Set(price), nil
+	})
+}
+
+// SuggestedGasPrice uses gas price suggested by the node multiplied by multiplier. Multiplier 1 is
+// the default strategy of the client.
+func SuggestedGasPrice(multiplier float64) GasPriceStrategy {
+	return GasPriceFunc(func(ctx context.Context, backend bind.ContractTransactor) (*big.Int, error) {
+		suggested, err := backend.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if multiplier == 1 {
+			return suggested, nil
+		}
+		price, _ := new(big.Float).Mul(new(big.Float).SetInt(suggested), big.NewFloat(multiplier)).Int(nil)
+		return price, nil
+	})
+}
+
+// CappedGasPrice uses gas price of the strategy but not higher than max.
+func CappedGasPrice(strategy GasPriceStrategy, max *big.Int) GasPriceStrategy {
+	max = new(big.Int).Set(max)
+	return GasPriceFunc(func(ctx context.Context, backend bind.ContractTransactor) (*big.Int, error) {
+		price, err := strategy.GasPrice(ctx, backend)
+		if err != nil {
+			return nil, err
+		}
+		if price.Cmp(max) > 0 {
+			return new(big.Int).Set(max), nil
+		}
+		return price, nil
+	})
+}
@@ -0,0 +1,91 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type suggestedBackend struct {
+	bind.ContractTransactor
+	price *big.Int
+}
+
+func (b suggestedBackend) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return b.price, nil
+}
+
+func TestGasPriceStrategies(t *testing.T) {
+	backend := suggestedBackend{price: big.NewInt(100)}
+	for _, tc := range []struct {
+		desc     string
+		strategy GasPriceStrategy
+		expected int64
+	}{
+		{"fixed", FixedGasPrice(big.NewInt(7)), 7},
+		{"suggested", SuggestedGasPrice(1), 100},
+		{"multiplied", SuggestedGasPrice(1.5), 150},
+		{"capped", CappedGasPrice(SuggestedGasPrice(2), big.NewInt(120)), 120},
+		{"under cap", CappedGasPrice(SuggestedGasPrice(1), big.NewInt(120)), 100},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			price, err := tc.strategy.GasPrice(context.Background(), backend)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, price.Int64())
+		})
+	}
+}
+
+// fundsBackend reports fixed balance and estimates gas only for calls without value.
+type fundsBackend struct {
+	ETHBackend
+	balance *big.Int
+	gas     uint64
+	err     error
+}
+
+func (b fundsBackend) BalanceAt(context.Context, common.Address, *big.Int) (*big.Int, error) {
+	return b.balance, nil
+}
+
+func (b fundsBackend) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(2), nil
+}
+
+func (b fundsBackend) EstimateGas(_ context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if msg.Value != nil && msg.Value.Cmp(b.balance) > 0 {
+		return 0, errors.New("insufficient funds for transfer")
+	}
+	return b.gas, b.err
+}
+
+func TestCheckFundsShortOfValue(t *testing.T) {
+	from := common.Address{2}
+	call := DelegateCall(common.Address{3}, big.NewInt(100))
+	for _, tc := range []struct {
+		desc     string
+		backend  fundsBackend
+		opts     []TxOption
+		required int64
+	}{
+		{"estimated", fundsBackend{balance: big.NewInt(50), gas: 1000}, nil, 2100},
+		{"gas limit", fundsBackend{balance: big.NewInt(50), gas: 1000}, []TxOption{WithGasLimit(300)}, 700},
+		{"estimation failed", fundsBackend{balance: big.NewInt(50), err: errors.New("execution reverted")}, nil, 100},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			client, err := NewClient(tc.backend, common.Address{1})
+			require.NoError(t, err)
+			_, _, err = client.checkFunds(context.Background(), from, call, client.newTxConfig(tc.opts))
+			var balanceErr *InsufficientBalanceError
+			require.True(t, errors.As(err, &balanceErr))
+			require.Equal(t, tc.required, balanceErr.Required.Int64())
+			require.Equal(t, tc.required-50, balanceErr.Shortfall().Int64())
+		})
+	}
+}
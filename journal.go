@@ -0,0 +1,262 @@
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// JournalEntry is a staking transaction that was signed and is not yet known to be mined.
+type JournalEntry struct {
+	Kind  TxKind         `json:"kind"`
+	From  common.Address `json:"from"`
+	Nonce uint64         `json:"nonce"`
+	// Hashes of the original transaction and all replacements.
+	Hashes []common.Hash `json:"hashes"`
+	// Cancels is a subset of Hashes that cancel original transaction.
+	Cancels       []common.Hash `json:"cancels,omitempty"`
+	Confirmations uint64        `json:"confirmations,omitempty"`
+	Created       time.Time     `json:"created"`
+}
+
+func (e JournalEntry) validate() error {
+	if len(e.Hashes) == 0 {
+		return fmt.Errorf("%v from 0x%x with nonce %d doesn't have hashes", e.Kind, e.From, e.Nonce)
+	}
+	return nil
+}
+
+// rejected is true if broadcast failed with an error returned by the node. Transaction that failed
+// to broadcast for another reason, e.g. timeout, may still reach the node and is kept in the journal.
+func rejected(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr)
+}
+
+// Journal persists submitted staking transactions, so that waiting can be resumed with Client.Resume
+// after restart. Entries are unique by From and Nonce.
+type Journal interface {
+	// Put inserts entry or replaces entry with the same From and Nonce.
+	Put(JournalEntry) error
+	Delete(from common.Address, nonce uint64) error
+	Entries() ([]JournalEntry, error)
+}
+
+type journalKey struct {
+	from  common.Address
+	nonce uint64
+}
+
+// NewMemoryJournal returns Journal that doesn't survive restarts. Can be used to resume waiting
+// for transactions that were submitted by another goroutine.
+func NewMemoryJournal() *MemoryJournal {
+	return &MemoryJournal{entries: map[journalKey]JournalEntry{}}
+}
+
+type MemoryJournal struct {
+	mu      sync.Mutex
+	entries map[journalKey]JournalEntry
+}
+
+func (j *MemoryJournal) Put(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries[journalKey{entry.From, entry.Nonce}] = entry
+	return nil
+}
+
+func (j *MemoryJournal) Delete(from common.Address, nonce uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.entries, journalKey{from, nonce})
+	return nil
+}
+
+// Entries returns entries ordered by sender and nonce.
+func (j *MemoryJournal) Entries() ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries := make([]JournalEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, k int) bool {
+		if entries[i].From != entries[k].From {
+			return entries[i].From.Hex() < entries[k].From.Hex()
+		}
+		return entries[i].Nonce < entries[k].Nonce
+	})
+	return entries, nil
+}
+
+// NewFileJournal opens journal stored as json in the file. File is created on the first write
+// and rewritten atomically on every change.
+func NewFileJournal(path string) (*FileJournal, error) {
+	j := &FileJournal{path: path, mem: NewMemoryJournal()}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []JournalEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if err := entry.validate(); err != nil {
+			return nil, fmt.Errorf("invalid journal %s: %w", path, err)
+		}
+		_ = j.mem.Put(entry)
+	}
+	return j, nil
+}
+
+type FileJournal struct {
+	path string
+
+	mu  sync.Mutex
+	mem *MemoryJournal
+}
+
+func (j *FileJournal) Put(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_ = j.mem.Put(entry)
+	return j.flush()
+}
+
+func (j *FileJournal) Delete(from common.Address, nonce uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_ = j.mem.Delete(from, nonce)
+	return j.flush()
+}
+
+func (j *FileJournal) Entries() ([]JournalEntry, error) {
+	return j.mem.Entries()
+}
+
+func (j *FileJournal) flush() error {
+	entries, _ := j.mem.Entries()
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(j.path), filepath.Base(j.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), j.path)
+}
+
+// record puts signed transaction into the journal before it is broadcasted.
+func (c *Client) record(kind TxKind, from common.Address, tx *types.Transaction, cfg txConfig) error {
+	return c.journal.Put(JournalEntry{
+		Kind:          kind,
+		From:          from,
+		Nonce:         tx.Nonce(),
+		Hashes:        []common.Hash{tx.Hash()},
+		Confirmations: cfg.confirmations,
+		Created:       time.Now(),
+	})
+}
+
+// ResumeResult is a final outcome of the journaled transaction.
+type ResumeResult struct {
+	Entry JournalEntry
+	Tx    TxResult
+	// Withdrawal is set for TxRequestWithdrawal and TxCompleteWithdrawals.
+	Withdrawal *WithdrawalInfo
+	// Err is an error returned by waiting, e.g. ErrTransactionReverted or ErrTransactionDropped.
+	Err error
+}
+
+// Resume waits for all transactions from the journal, configured with WithJournal, and returns their final
+// outcomes in the journal order. Entries are removed from the journal once outcome is known, including
+// ErrTransactionDropped for transactions that node doesn't know about.
+func (c *Client) Resume(ctx context.Context) ([]ResumeResult, error) {
+	if c.journal == nil {
+		return nil, nil
+	}
+	entries, err := c.journal.Entries()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ResumeResult, len(entries))
+	var wg sync.WaitGroup
+	for i := range entries {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = c.resume(ctx, entries[i])
+		}(i)
+	}
+	wg.Wait()
+	return results, ctx.Err()
+}
+
+func (c *Client) resume(ctx context.Context, entry JournalEntry) ResumeResult {
+	result := ResumeResult{Entry: entry}
+	if result.Err = entry.validate(); result.Err != nil {
+		return result
+	}
+	tx := &PendingTx{
+		Kind:      entry.Kind,
+		Hash:      entry.Hashes[0],
+		Nonce:     entry.Nonce,
+		client:    c,
+		cfg:       txConfig{confirmations: entry.Confirmations},
+		from:      entry.From,
+		journaled: true,
+		created:   entry.Created,
+		desc:      fmt.Sprintf("%v from 0x%x with nonce %d", entry.Kind, entry.From, entry.Nonce),
+	}
+	cancels := map[common.Hash]bool{}
+	for _, hash := range entry.Cancels {
+		cancels[hash] = true
+	}
+	for _, hash := range entry.Hashes {
+		tx.sent = append(tx.sent, sentTx{hash: hash, cancel: cancels[hash]})
+	}
+	// broadcast might have failed before transaction reached the node.
+	if tx.unknown(ctx) {
+		tx.forget()
+		result.Err = fmt.Errorf("%w: %s", ErrTransactionDropped, tx.desc)
+		return result
+	}
+	if entry.Kind == TxRequestWithdrawal || entry.Kind == TxCompleteWithdrawals {
+		info, err := (&PendingWithdrawal{PendingTx: tx}).Wait(ctx)
+		result.Tx, result.Err = info.Tx, err
+		if err == nil {
+			result.Withdrawal = &info
+		}
+		return result
+	}
+	result.Tx, result.Err = tx.Wait(ctx)
+	return result
+}
@@ -0,0 +1,74 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileJournalReopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "staking-journal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal.json")
+
+	j, err := NewFileJournal(path)
+	require.NoError(t, err)
+	first := JournalEntry{
+		Kind:    TxDelegate,
+		From:    common.Address{1},
+		Nonce:   1,
+		Hashes:  []common.Hash{{1}, {2}},
+		Cancels: []common.Hash{{2}},
+		Created: time.Unix(100, 0).UTC(),
+	}
+	second := JournalEntry{
+		Kind:          TxRequestWithdrawal,
+		From:          common.Address{1},
+		Nonce:         2,
+		Hashes:        []common.Hash{{3}},
+		Confirmations: 3,
+		Created:       time.Unix(200, 0).UTC(),
+	}
+	require.NoError(t, j.Put(second))
+	require.NoError(t, j.Put(first))
+	require.NoError(t, j.Put(JournalEntry{From: common.Address{2}, Hashes: []common.Hash{{4}}}))
+	require.NoError(t, j.Delete(common.Address{2}, 0))
+
+	reopened, err := NewFileJournal(path)
+	require.NoError(t, err)
+	entries, err := reopened.Entries()
+	require.NoError(t, err)
+	require.Equal(t, []JournalEntry{first, second}, entries)
+}
+
+func TestFileJournalInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "staking-journal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal.json")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[{"kind":0,"from":"0x0000000000000000000000000000000000000001","nonce":1}]`), 0600))
+	_, err = NewFileJournal(path)
+	require.Error(t, err)
+}
+
+func TestRejected(t *testing.T) {
+	require.True(t, rejected(fmt.Errorf("send: %w", nodeError("nonce too low"))))
+	require.False(t, rejected(context.DeadlineExceeded))
+	require.False(t, rejected(errors.New("connection reset by peer")))
+}
+
+// nodeError is an error returned by the node over json-rpc.
+type nodeError string
+
+func (e nodeError) Error() string  { return string(e) }
+func (e nodeError) ErrorCode() int { return -32000 }
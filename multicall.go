@@ -0,0 +1,195 @@
+package staking
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BatchCaller sends several json-rpc requests in one round trip. Implemented by *rpc.Client.
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// retryBatchCaller retries batches according to the policy.
+type retryBatchCaller struct {
+	BatchCaller
+	policy RetryPolicy
+}
+
+func (b retryBatchCaller) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	return retry(ctx, b.policy, func() error {
+		return b.BatchCaller.BatchCallContext(ctx, batch)
+	})
+}
+
+// maxBatchSize is a max number of calls in a single json-rpc batch or aggregate call.
+const maxBatchSize = 100
+
+// multicallABI is the aggregate method of the Multicall contract. Aggregate reverts if any call reverts.
+const multicallABI = `[{"constant":false,"inputs":[{"components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}],"name":"calls","type":"tuple[]"}],"name":"aggregate","outputs":[{"name":"blockNumber","type":"uint256"},{"name":"returnData","type":"bytes[]"}],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+
+var parsedMulticall = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(multicallABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+type multicallCall struct {
+	Target   common.Address
+	CallData []byte
+}
+
+// readCall is a read of the staking contract, result is unpacked into out.
+type readCall struct {
+	method string
+	args   []interface{}
+	out    interface{}
+}
+
+// batchRead executes calls at the block with Multicall contract or json-rpc batches.
+func (c *Client) batchRead(ctx context.Context, block *big.Int, calls []readCall) error {
+	for start := 0; start < len(calls); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(calls) {
+			end = len(calls)
+		}
+		chunk := calls[start:end]
+		data := make([][]byte, len(chunk))
+		for i, call := range chunk {
+			packed, err := c.abi.Pack(call.method, call.args...)
+			if err != nil {
+				return err
+			}
+			data[i] = packed
+		}
+		var (
+			results [][]byte
+			err     error
+		)
+		if c.multicall != nil {
+			results, err = c.aggregate(ctx, block, data)
+		} else {
+			results, err = c.batchCall(ctx, block, data)
+		}
+		if err != nil {
+			return err
+		}
+		for i, call := range chunk {
+			if err := c.abi.Unpack(call.out, call.method, results[i]); err != nil {
+				return fmt.Errorf("failed to unpack %s: %w", call.method, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Client) aggregate(ctx context.Context, block *big.Int, data [][]byte) ([][]byte, error) {
+	calls := make([]multicallCall, len(data))
+	for i := range data {
+		calls[i] = multicallCall{Target: c.address, CallData: data[i]}
+	}
+	input, err := parsedMulticall.Pack("aggregate", calls)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.client.CallContract(ctx, ethereum.CallMsg{To: c.multicall, Data: input}, block)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		BlockNumber *big.Int
+		ReturnData  [][]byte
+	}
+	if err := parsedMulticall.Unpack(&result, "aggregate", out); err != nil {
+		return nil, err
+	}
+	if len(result.ReturnData) != len(data) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(result.ReturnData), len(data))
+	}
+	return result.ReturnData, nil
+}
+
+func (c *Client) batchCall(ctx context.Context, block *big.Int, data [][]byte) ([][]byte, error) {
+	number := "latest"
+	if block != nil {
+		number = hexutil.EncodeBig(block)
+	}
+	results := make([]hexutil.Bytes, len(data))
+	batch := make([]rpc.BatchElem, len(data))
+	for i := range data {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{"to": c.address, "data": hexutil.Bytes(data[i])},
+				number,
+			},
+			Result: &results[i],
+		}
+	}
+	if err := c.batchCaller.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+	out := make([][]byte, len(data))
+	for i := range batch {
+		if batch[i].Error != nil {
+			return nil, batch[i].Error
+		}
+		out[i] = results[i]
+	}
+	return out, nil
+}
+
+// batchTranscoders reads transcoders with two rounds of batched calls: addresses by index and then
+// info, state and self stake of every address.
+func (c *Client) batchTranscoders(ctx context.Context, opts []ReadOption) ([]Transcoder, error) {
+	count, err := c.TranscodersCount(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	block := c.callOpts(ctx, opts).BlockNumber
+	addresses := make([]common.Address, count.Int64())
+	calls := make([]readCall, 0, 3*len(addresses))
+	for i := range addresses {
+		calls = append(calls, readCall{method: "transcodersArray", args: []interface{}{big.NewInt(int64(i))}, out: &addresses[i]})
+	}
+	if err := c.batchRead(ctx, block, calls); err != nil {
+		return nil, err
+	}
+	var (
+		infos      = make([]transcoderInfo, len(addresses))
+		states     = make([]uint8, len(addresses))
+		selfStakes = make([]*big.Int, len(addresses))
+	)
+	calls = calls[:0]
+	for i, address := range addresses {
+		calls = append(calls,
+			readCall{method: "transcoders", args: []interface{}{address}, out: &infos[i]},
+			readCall{method: "getTranscoderState", args: []interface{}{address}, out: &states[i]},
+			readCall{method: "getSelfStake", args: []interface{}{address}, out: &selfStakes[i]},
+		)
+	}
+	if err := c.batchRead(ctx, block, calls); err != nil {
+		return nil, err
+	}
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	tcrs := make([]Transcoder, len(addresses))
+	for i, address := range addresses {
+		if infos[i].Timestamp == nil || infos[i].Timestamp.Sign() == 0 {
+			return nil, &TranscoderError{Transcoder: address, Err: ErrTranscoderNotRegistered}
+		}
+		tcrs[i] = newTranscoder(address, infos[i], State(states[i]), selfStakes[i])
+	}
+	return tcrs, nil
+}
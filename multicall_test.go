@@ -0,0 +1,200 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"github.com/videocoin/go-contracts/bindings/staking"
+)
+
+// echoMulticall returns call data of every aggregated call as its result.
+type echoMulticall struct {
+	ETHBackend
+	target common.Address
+}
+
+func (b *echoMulticall) CallContract(ctx context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	args, err := parsedMulticall.Methods["aggregate"].Inputs.UnpackValues(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	calls := reflect.ValueOf(args[0])
+	results := make([][]byte, calls.Len())
+	for i := range results {
+		call := calls.Index(i)
+		b.target = call.FieldByName("Target").Interface().(common.Address)
+		results[i] = call.FieldByName("CallData").Bytes()
+	}
+	return parsedMulticall.Methods["aggregate"].Outputs.Pack(big.NewInt(1), results)
+}
+
+func TestAggregate(t *testing.T) {
+	backend := &echoMulticall{}
+	c := &Client{client: backend, address: common.Address{1}, multicall: &common.Address{2}}
+
+	data := [][]byte{{1}, {2, 3}, make([]byte, 68)}
+	results, err := c.aggregate(context.Background(), nil, data)
+	require.NoError(t, err)
+	require.Equal(t, data, results)
+	require.Equal(t, c.address, backend.target)
+}
+
+type flakyBatchCaller struct {
+	failures int
+	calls    int
+}
+
+func (b *flakyBatchCaller) BatchCallContext(context.Context, []rpc.BatchElem) error {
+	b.calls++
+	if b.calls <= b.failures {
+		return errors.New("connection reset by peer")
+	}
+	return nil
+}
+
+func TestRetryBatchCaller(t *testing.T) {
+	flaky := &flakyBatchCaller{failures: 1}
+	caller := retryBatchCaller{BatchCaller: flaky, policy: RetryPolicy{MaxAttempts: 2}}
+	require.NoError(t, caller.BatchCallContext(context.Background(), nil))
+	require.Equal(t, 2, flaky.calls)
+}
+
+// stakingBackend serves reads of the staking contract with registered transcoders and executes
+// aggregated calls of the multicall contract one by one.
+type stakingBackend struct {
+	ETHBackend
+	abi         abi.ABI
+	multicall   common.Address
+	transcoders []Transcoder
+	// revert fails every aggregate call.
+	revert bool
+	// failures fail reads of the transcoder info after the delay.
+	failures map[common.Address]time.Duration
+
+	mu         sync.Mutex
+	aggregated int
+	calls      int
+}
+
+func (b *stakingBackend) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(10)}, nil
+}
+
+func (b *stakingBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	b.mu.Lock()
+	if *msg.To != b.multicall {
+		b.calls++
+		b.mu.Unlock()
+		return b.call(msg.Data)
+	}
+	b.aggregated++
+	b.mu.Unlock()
+	if b.revert {
+		return nil, nodeError("execution reverted")
+	}
+	args, err := parsedMulticall.Methods["aggregate"].Inputs.UnpackValues(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	calls := reflect.ValueOf(args[0])
+	results := make([][]byte, calls.Len())
+	for i := range results {
+		results[i], err = b.call(calls.Index(i).FieldByName("CallData").Bytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return parsedMulticall.Methods["aggregate"].Outputs.Pack(block, results)
+}
+
+func (b *stakingBackend) call(data []byte) ([]byte, error) {
+	method, err := b.abi.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, err
+	}
+	if method.Name == "transcodersCount" {
+		return method.Outputs.Pack(big.NewInt(int64(len(b.transcoders))))
+	}
+	if method.Name == "transcodersArray" {
+		return method.Outputs.Pack(b.transcoders[args[0].(*big.Int).Int64()].Address)
+	}
+	for _, tcr := range b.transcoders {
+		if tcr.Address != args[0].(common.Address) {
+			continue
+		}
+		switch method.Name {
+		case "transcoders":
+			if delay, exist := b.failures[tcr.Address]; exist {
+				time.Sleep(delay)
+				return nil, fmt.Errorf("transcoder 0x%x is not available", tcr.Address)
+			}
+			return method.Outputs.Pack(tcr.TotalStake, tcr.Capacity, big.NewInt(10),
+				new(big.Int).SetUint64(tcr.Timestamp), tcr.EffectiveMinSelfStake)
+		case "getTranscoderState":
+			return method.Outputs.Pack(uint8(tcr.State))
+		case "getSelfStake":
+			return method.Outputs.Pack(tcr.SelfStake)
+		}
+	}
+	return nil, fmt.Errorf("unexpected call of %s", method.Name)
+}
+
+func TestMulticallTranscoders(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(staking.StakingManagerABI))
+	require.NoError(t, err)
+	var transcoders []Transcoder
+	for i, state := range []State{StateBonded, StateBonding, StateUnbonded} {
+		transcoders = append(transcoders, Transcoder{
+			Address:               common.BytesToAddress([]byte{byte(i + 1)}),
+			State:                 state,
+			TotalStake:            big.NewInt(int64(100 * (i + 1))),
+			SelfStake:             big.NewInt(int64(10 * (i + 1))),
+			DelegatedStake:        big.NewInt(int64(90 * (i + 1))),
+			Capacity:              big.NewInt(1000),
+			Timestamp:             uint64(1000 + i),
+			EffectiveMinSelfStake: big.NewInt(5),
+		})
+	}
+
+	backend := &stakingBackend{abi: parsed, multicall: common.Address{2}, transcoders: transcoders}
+	client, err := NewClient(backend, common.Address{1}, WithMulticall(backend.multicall))
+	require.NoError(t, err)
+	all, err := client.GetAllTranscoders(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, transcoders, all)
+	bonded, err := client.GetBondedTranscoders(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, transcoders[:1], bonded)
+	// count is read with a call, addresses and transcoders with an aggregate call each
+	require.Equal(t, 4, backend.aggregated)
+	require.Equal(t, 2, backend.calls)
+
+	t.Run("reverted", func(t *testing.T) {
+		backend := &stakingBackend{abi: parsed, multicall: common.Address{2}, transcoders: transcoders, revert: true}
+		client, err := NewClient(backend, common.Address{1}, WithMulticall(backend.multicall))
+		require.NoError(t, err)
+		all, err := client.GetAllTranscoders(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, transcoders, all)
+		require.Equal(t, 1, backend.aggregated)
+		// count is read twice, then address, info, state and self stake of every transcoder
+		require.Equal(t, 2+4*len(transcoders), backend.calls)
+	})
+}
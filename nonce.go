@@ -0,0 +1,76 @@
+package staking
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type pendingNonceReader interface {
+	PendingNonceAt(context.Context, common.Address) (uint64, error)
+}
+
+func newNonceManager(backend pendingNonceReader) *nonceManager {
+	return &nonceManager{
+		backend:  backend,
+		accounts: map[common.Address]*accountNonce{},
+	}
+}
+
+// nonceManager assigns nonces for transactions sent from the same account. Transactions from
+// the same account are serialized, so that they are broadcasted in the nonce order.
+type nonceManager struct {
+	backend pendingNonceReader
+
+	mu       sync.Mutex
+	accounts map[common.Address]*accountNonce
+}
+
+type accountNonce struct {
+	// mu is held from nonce assignment until transaction is broadcasted.
+	mu     sync.Mutex
+	next   uint64
+	synced bool
+}
+
+func (m *nonceManager) account(address common.Address) *accountNonce {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	acc, exist := m.accounts[address]
+	if !exist {
+		acc = &accountNonce{}
+		m.accounts[address] = acc
+	}
+	return acc
+}
+
+// Send assigns next nonce for the address and calls send with it. If send fails nonce is not consumed
+// and will be synced with pending nonce from the backend before next transaction.
+func (m *nonceManager) Send(ctx context.Context, address common.Address, send func(nonce uint64) error) error {
+	acc := m.account(address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	if !acc.synced {
+		pending, err := m.backend.PendingNonceAt(ctx, address)
+		if err != nil {
+			return err
+		}
+		acc.next = pending
+		acc.synced = true
+	}
+	if err := send(acc.next); err != nil {
+		acc.synced = false
+		return err
+	}
+	acc.next++
+	return nil
+}
+
+// Reset forces nonce for the address to be synced with the backend before next transaction.
+func (m *nonceManager) Reset(address common.Address) {
+	acc := m.account(address)
+	acc.mu.Lock()
+	acc.synced = false
+	acc.mu.Unlock()
+}
@@ -0,0 +1,41 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type pendingNonceFunc func(context.Context, common.Address) (uint64, error)
+
+func (f pendingNonceFunc) PendingNonceAt(ctx context.Context, address common.Address) (uint64, error) {
+	return f(ctx, address)
+}
+
+func TestNonceManagerResyncAfterFailure(t *testing.T) {
+	var (
+		pending uint64 = 5
+		address        = common.Address{1}
+		nonces  []uint64
+	)
+	m := newNonceManager(pendingNonceFunc(func(context.Context, common.Address) (uint64, error) {
+		return pending, nil
+	}))
+	record := func(nonce uint64) error {
+		nonces = append(nonces, nonce)
+		return nil
+	}
+
+	require.NoError(t, m.Send(context.Background(), address, record))
+	require.NoError(t, m.Send(context.Background(), address, record))
+	require.Error(t, m.Send(context.Background(), address, func(uint64) error {
+		return errors.New("nonce too low")
+	}))
+
+	pending = 10
+	require.NoError(t, m.Send(context.Background(), address, record))
+	require.Equal(t, []uint64{5, 6, 10}, nonces)
+}
@@ -0,0 +1,99 @@
+package staking
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+//go:generate stringer -type=TxEventType
+type TxEventType uint8
+
+const (
+	// TxBuilt unsigned transaction was created. Replacements are reported as separate transactions.
+	TxBuilt TxEventType = iota
+	// TxSigned transaction was signed by the client. Envelopes signed with SignEnvelope are not reported.
+	TxSigned
+	// TxBroadcasted transaction was accepted by the node.
+	TxBroadcasted
+	// TxMined transaction was mined with success status.
+	TxMined
+	// TxReverted transaction was mined with failed status.
+	TxReverted
+	// TxConfirmed block with transaction has required number of confirmations.
+	TxConfirmed
+)
+
+// TxEvent is a notification about staking transaction.
+type TxEvent struct {
+	Type TxEventType
+	Kind TxKind
+	// From is unknown (zero) for attached transactions.
+	From  common.Address
+	Nonce uint64
+	Hash  common.Hash
+	// Tx is nil for mined transactions that were attached.
+	Tx *types.Transaction
+	// Receipt is set for TxMined, TxReverted and TxConfirmed.
+	Receipt *types.Receipt
+}
+
+// Observer is notified about every staking transaction of the client. Observe is called synchronously
+// from goroutines that submit and wait for transactions, it must be safe for concurrent use and must not block.
+type Observer interface {
+	Observe(TxEvent)
+}
+
+// ObserverFunc is an adapter to use ordinary function as Observer.
+type ObserverFunc func(TxEvent)
+
+func (f ObserverFunc) Observe(event TxEvent) {
+	f(event)
+}
+
+func (c *Client) notify(event TxEvent) {
+	for _, observer := range c.observers {
+		observer.Observe(event)
+	}
+}
+
+func (c *Client) notifyTx(typ TxEventType, kind TxKind, from common.Address, tx *types.Transaction) {
+	if len(c.observers) == 0 {
+		return
+	}
+	c.notify(TxEvent{Type: typ, Kind: kind, From: from, Nonce: tx.Nonce(), Hash: tx.Hash(), Tx: tx})
+}
+
+// notifyReceipt notifies about mined transaction.
+func (tx *PendingTx) notifyReceipt(typ TxEventType, receipt *types.Receipt) {
+	if len(tx.client.observers) == 0 {
+		return
+	}
+	mined, _ := tx.find(receipt.TxHash)
+	tx.client.notify(TxEvent{
+		Type:    typ,
+		Kind:    tx.Kind,
+		From:    tx.from,
+		Nonce:   tx.Nonce,
+		Hash:    receipt.TxHash,
+		Tx:      mined.tx,
+		Receipt: receipt,
+	})
+}
+
+// sign notifies about built transaction and signs it for the chain. Returns ErrChainIDMismatch if signer
+// didn't sign transaction with replay protection for the chain.
+func (c *Client) sign(kind TxKind, signer Signer, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	c.notifyTx(TxBuilt, kind, signer.Address(), tx)
+	signed, err := signer.SignTx(tx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if !signed.Protected() || signed.ChainId().Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("%w: transaction 0x%x is not signed for chain %v", ErrChainIDMismatch, signed.Hash(), chainID)
+	}
+	c.notifyTx(TxSigned, kind, signer.Address(), signed)
+	return signed, nil
+}
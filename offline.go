@@ -0,0 +1,217 @@
+package staking
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Envelope is a staking transaction prepared for signing on another machine. Envelope is portable
+// as json and carries everything that is required to sign it offline with SignEnvelope.
+type Envelope struct {
+	Kind     TxKind         `json:"kind"`
+	From     common.Address `json:"from"`
+	To       common.Address `json:"to"`
+	Nonce    uint64         `json:"nonce"`
+	Gas      uint64         `json:"gas"`
+	GasPrice *big.Int       `json:"gasPrice"`
+	Value    *big.Int       `json:"value"`
+	Data     hexutil.Bytes  `json:"data"`
+	ChainID  *big.Int       `json:"chainId"`
+	// Signed is rlp encoded signed transaction. Set by SignEnvelope.
+	Signed hexutil.Bytes `json:"signed,omitempty"`
+}
+
+// Transaction returns unsigned transaction.
+func (e *Envelope) Transaction() *types.Transaction {
+	return types.NewTransaction(e.Nonce, e.To, e.Value, e.Gas, e.GasPrice, e.Data)
+}
+
+// SignEnvelope signs envelope with the signer of the envelope sender. Doesn't require connection to the node.
+func SignEnvelope(env *Envelope, signer Signer) error {
+	if env.ChainID == nil {
+		return fmt.Errorf("%w: envelope doesn't have chain id", ErrChainIDUnknown)
+	}
+	if signer.Address() != env.From {
+		return fmt.Errorf("signer for 0x%x can't sign transaction from 0x%x", signer.Address(), env.From)
+	}
+	signed, err := signer.SignTx(env.Transaction(), env.ChainID)
+	if err != nil {
+		return err
+	}
+	env.Signed, err = rlp.EncodeToBytes(signed)
+	return err
+}
+
+// build returns envelope for the call with pending nonce of the sender, unless nonce is set with WithNonce.
+// Nonce is not reserved, transactions that are sent by the client from the same account before broadcast
+// will invalidate envelope.
+func (c *Client) build(ctx context.Context, from common.Address, call Call, opts []TxOption) (*Envelope, error) {
+	cfg := c.newTxConfig(opts)
+	gas, gasPrice, err := c.checkFunds(ctx, from, call, cfg)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := c.callMsg(from, call)
+	if err != nil {
+		return nil, err
+	}
+	var nonce uint64
+	if cfg.nonce != nil {
+		nonce = *cfg.nonce
+	} else {
+		nonce, err = c.client.PendingNonceAt(ctx, from)
+		if err != nil {
+			return nil, err
+		}
+	}
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value := call.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	env := &Envelope{
+		Kind:     call.Kind,
+		From:     from,
+		To:       c.address,
+		Nonce:    nonce,
+		Gas:      gas,
+		GasPrice: gasPrice,
+		Value:    value,
+		Data:     msg.Data,
+		ChainID:  chainID,
+	}
+	c.notifyTx(TxBuilt, call.Kind, from, env.Transaction())
+	return env, nil
+}
+
+// BuildDelegate returns unsigned delegation of the amount from the delegator to the transcoder.
+func (c *Client) BuildDelegate(ctx context.Context, delegator, to common.Address, amount *big.Int,
+	opts ...TxOption) (*Envelope, error) {
+	if err := c.checkDelegate(ctx, to, amount); err != nil {
+		return nil, err
+	}
+	return c.build(ctx, delegator, DelegateCall(to, amount), opts)
+}
+
+// BuildRegisterTranscoder returns unsigned registration of the transcoder.
+func (c *Client) BuildRegisterTranscoder(ctx context.Context, transcoder common.Address, rewardRate uint64,
+	opts ...TxOption) (*Envelope, error) {
+	if err := c.checkRegisterTranscoder(ctx, transcoder); err != nil {
+		return nil, err
+	}
+	return c.build(ctx, transcoder, RegisterTranscoderCall(rewardRate), opts)
+}
+
+// BuildRequestWithdrawal returns unsigned unbonding request of the amount that delegator delegated to the transcoder.
+func (c *Client) BuildRequestWithdrawal(ctx context.Context, delegator, from common.Address, amount *big.Int,
+	opts ...TxOption) (*Envelope, error) {
+	if err := c.checkRequestWithdrawal(ctx, delegator, from, amount); err != nil {
+		return nil, err
+	}
+	return c.build(ctx, delegator, RequestWithdrawalCall(from, amount), opts)
+}
+
+// BuildCompleteWithdrawals returns unsigned completion of all pending withdrawals of the delegator.
+func (c *Client) BuildCompleteWithdrawals(ctx context.Context, delegator common.Address, opts ...TxOption) (*Envelope, error) {
+	if err := c.checkCompleteWithdrawals(ctx, delegator); err != nil {
+		return nil, err
+	}
+	return c.build(ctx, delegator, CompleteWithdrawalsCall(), opts)
+}
+
+// Broadcast submits signed envelope and returns without waiting for transaction to be mined.
+// Use BroadcastWithdrawal for TxRequestWithdrawal and TxCompleteWithdrawals to get parsed withdrawal info.
+func (c *Client) Broadcast(ctx context.Context, env *Envelope, opts ...TxOption) (*PendingTx, error) {
+	if len(env.Signed) == 0 {
+		return nil, fmt.Errorf("%v from 0x%x with nonce %d is not signed", env.Kind, env.From, env.Nonce)
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(env.Signed, tx); err != nil {
+		return nil, err
+	}
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !tx.Protected() || tx.ChainId().Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("%w: transaction 0x%x is not signed for chain %v", ErrChainIDMismatch, tx.Hash(), chainID)
+	}
+	sender, err := types.Sender(txSigner(chainID), tx)
+	if err != nil {
+		return nil, err
+	}
+	if sender != env.From {
+		return nil, fmt.Errorf("transaction 0x%x is signed by 0x%x instead of 0x%x", tx.Hash(), sender, env.From)
+	}
+	if tx.To() == nil || *tx.To() != c.address {
+		return nil, fmt.Errorf("transaction 0x%x is not sent to staking contract 0x%x", tx.Hash(), c.address)
+	}
+	if err := c.checkSigned(env, tx); err != nil {
+		return nil, err
+	}
+	cfg := c.newTxConfig(opts)
+	if c.journal != nil {
+		if err := c.record(env.Kind, sender, tx, cfg); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.client.SendTransaction(ctx, tx); err != nil {
+		if c.journal != nil && rejected(err) {
+			_ = c.journal.Delete(sender, tx.Nonce())
+		}
+		return nil, err
+	}
+	// nonce of the account was used bypassing nonce manager.
+	c.nonces.Reset(sender)
+	c.notifyTx(TxBroadcasted, env.Kind, sender, tx)
+	return c.newPendingTx(env.Kind, sender, nil, tx, cfg,
+		fmt.Sprintf("%v from 0x%x with nonce %d", env.Kind, sender, tx.Nonce()),
+	), nil
+}
+
+// checkSigned ensures that signed transaction calls the method of the envelope kind with the envelope nonce
+// and value. Kind decides how transaction is journaled and awaited, so it can't be trusted otherwise.
+func (c *Client) checkSigned(env *Envelope, tx *types.Transaction) error {
+	if tx.Nonce() != env.Nonce {
+		return fmt.Errorf("transaction 0x%x has nonce %d instead of %d", tx.Hash(), tx.Nonce(), env.Nonce)
+	}
+	value := env.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	if tx.Value().Cmp(value) != 0 {
+		return fmt.Errorf("transaction 0x%x has value %v instead of %v", tx.Hash(), tx.Value(), value)
+	}
+	if len(tx.Data()) < 4 {
+		return fmt.Errorf("transaction 0x%x doesn't call staking contract", tx.Hash())
+	}
+	method, err := c.abi.MethodById(tx.Data()[:4])
+	if err != nil {
+		return fmt.Errorf("transaction 0x%x: %w", tx.Hash(), err)
+	}
+	if method.Name != kindMethods[env.Kind] {
+		return fmt.Errorf("transaction 0x%x calls %s instead of %v", tx.Hash(), method.Name, env.Kind)
+	}
+	return nil
+}
+
+// BroadcastWithdrawal submits signed TxRequestWithdrawal or TxCompleteWithdrawals envelope.
+func (c *Client) BroadcastWithdrawal(ctx context.Context, env *Envelope, opts ...TxOption) (*PendingWithdrawal, error) {
+	if env.Kind != TxRequestWithdrawal && env.Kind != TxCompleteWithdrawals {
+		return nil, fmt.Errorf("%v is not a withdrawal", env.Kind)
+	}
+	tx, err := c.Broadcast(ctx, env, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &PendingWithdrawal{PendingTx: tx}, nil
+}
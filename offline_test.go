@@ -0,0 +1,60 @@
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastMismatchedEnvelope(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := NewKeySigner(key)
+	backend := &poolBackend{receipts: map[common.Hash]*types.Receipt{}}
+	client, err := NewClient(backend, common.Address{1}, WithChainID(big.NewInt(1)))
+	require.NoError(t, err)
+	data, err := client.abi.Pack("withdrawAllPending")
+	require.NoError(t, err)
+
+	envelope := func() *Envelope {
+		env := &Envelope{
+			Kind:     TxCompleteWithdrawals,
+			From:     signer.Address(),
+			To:       client.address,
+			Nonce:    3,
+			Gas:      100000,
+			GasPrice: big.NewInt(1),
+			Value:    new(big.Int),
+			Data:     data,
+			ChainID:  big.NewInt(1),
+		}
+		require.NoError(t, SignEnvelope(env, signer))
+		return env
+	}
+	for _, tc := range []struct {
+		desc   string
+		modify func(*Envelope)
+	}{
+		{"kind", func(env *Envelope) { env.Kind = TxRequestWithdrawal }},
+		{"nonce", func(env *Envelope) { env.Nonce = 4 }},
+		{"value", func(env *Envelope) { env.Value = big.NewInt(10) }},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			env := envelope()
+			tc.modify(env)
+			_, err := client.Broadcast(context.Background(), env)
+			require.Error(t, err)
+			require.Empty(t, backend.transactions())
+		})
+	}
+
+	tx, err := client.Broadcast(context.Background(), envelope())
+	require.NoError(t, err)
+	require.Len(t, backend.transactions(), 1)
+	require.Equal(t, []common.Hash{backend.transactions()[0].Hash()}, tx.Hashes())
+}
@@ -0,0 +1,184 @@
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ClientOption configures optional behaviour of the Client.
+type ClientOption func(*Client)
+
+// WithBumpPolicy enables automatic replacement of transactions that are not mined in time.
+// Applies only to transactions submitted by the same client instance.
+func WithBumpPolicy(policy BumpPolicy) ClientOption {
+	return func(c *Client) {
+		c.bumpPolicy = &policy
+	}
+}
+
+// WithJournal records every submitted transaction in the journal before it is broadcasted.
+// Transactions that were not confirmed before restart can be awaited with Client.Resume.
+func WithJournal(journal Journal) ClientOption {
+	return func(c *Client) {
+		c.journal = journal
+	}
+}
+
+// WithChainID sets chain id that is used to sign transactions with replay protection. Required if backend
+// doesn't report chain id. Otherwise it is verified against the chain id reported by the node.
+func WithChainID(chainID *big.Int) ClientOption {
+	return func(c *Client) {
+		c.chainID = new(big.Int).Set(chainID)
+	}
+}
+
+// WithObserver registers observer that is notified about every staking transaction of the client.
+func WithObserver(observer Observer) ClientOption {
+	return func(c *Client) {
+		c.observers = append(c.observers, observer)
+	}
+}
+
+// WithRetryPolicy retries requests to the backend, including contract calls and receipt polling,
+// if they fail with transient errors. Broadcast of the transaction is never retried.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+// WithFetchConcurrency fetches up to n transcoders concurrently in GetAllTranscoders and GetBondedTranscoders.
+// TranscoderIterator always fetches one transcoder at a time.
+func WithFetchConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		c.fetchConcurrency = n
+	}
+}
+
+// WithBatchCaller sends transcoder reads of GetAllTranscoders and GetBondedTranscoders as batched json-rpc
+// requests, e.g. with *rpc.Client that the backend was dialed with. Batches are retried according to
+// WithRetryPolicy, but are sent only to the caller and don't fail over with FailoverBackend. If batch
+// fails transcoders are read call by call with the backend.
+func WithBatchCaller(caller BatchCaller) ClientOption {
+	return func(c *Client) {
+		c.batchCaller = caller
+	}
+}
+
+// WithMulticall reads transcoders in GetAllTranscoders and GetBondedTranscoders with the aggregate method
+// of the Multicall contract deployed at the address. Takes precedence over WithBatchCaller.
+func WithMulticall(address common.Address) ClientOption {
+	return func(c *Client) {
+		c.multicall = &address
+	}
+}
+
+// WithTxDefaults applies options to every transaction of the client. Options passed to individual
+// methods take precedence.
+func WithTxDefaults(opts ...TxOption) ClientOption {
+	return func(c *Client) {
+		c.txDefaults = append(c.txDefaults, opts...)
+	}
+}
+
+// TxOption configures individual staking transaction.
+type TxOption func(*txConfig)
+
+type txConfig struct {
+	confirmations uint64
+	simulate      bool
+	// gasPrice is nil if node suggested gas price is used.
+	gasPrice GasPriceStrategy
+	// gasLimit is 0 if gas is estimated.
+	gasLimit uint64
+	// nonce is nil if nonce is assigned by the nonce manager.
+	nonce *uint64
+}
+
+func (c *Client) newTxConfig(opts []TxOption) txConfig {
+	var cfg txConfig
+	for _, opt := range c.txDefaults {
+		opt(&cfg)
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithConfirmations makes Wait return only after the block with transaction has n confirmations,
+// including the block itself, and is still canonical. 0 and 1 are equivalent and wait only for
+// the transaction to be mined.
+func WithConfirmations(n uint64) TxOption {
+	return func(cfg *txConfig) {
+		cfg.confirmations = n
+	}
+}
+
+// WithSimulation executes transaction with eth_call before broadcasting it. If it reverts
+// transaction is not broadcasted and *RevertError is returned.
+func WithSimulation() TxOption {
+	return func(cfg *txConfig) {
+		cfg.simulate = true
+	}
+}
+
+// WithGasPrice signs transaction with fixed gas price.
+func WithGasPrice(price *big.Int) TxOption {
+	return WithGasPriceStrategy(FixedGasPrice(price))
+}
+
+// WithGasPriceStrategy uses strategy to decide gas price of the transaction.
+func WithGasPriceStrategy(strategy GasPriceStrategy) TxOption {
+	return func(cfg *txConfig) {
+		cfg.gasPrice = strategy
+	}
+}
+
+// WithGasLimit uses gas limit instead of the estimated one. Estimation is skipped.
+func WithGasLimit(gas uint64) TxOption {
+	return func(cfg *txConfig) {
+		cfg.gasLimit = gas
+	}
+}
+
+// WithNonce signs transaction with nonce, bypassing nonce manager. Can be used to replace transaction
+// that was sent by another tool.
+func WithNonce(nonce uint64) TxOption {
+	return func(cfg *txConfig) {
+		cfg.nonce = &nonce
+	}
+}
+
+// ReadOption configures reads of the staking contract state.
+type ReadOption func(*bind.CallOpts)
+
+// AtBlock reads state of the contract at the block. Nil is the latest block.
+func AtBlock(number *big.Int) ReadOption {
+	return func(opts *bind.CallOpts) {
+		opts.BlockNumber = number
+	}
+}
+
+func (c *Client) callOpts(ctx context.Context, opts []ReadOption) *bind.CallOpts {
+	call := &bind.CallOpts{Context: ctx}
+	for _, opt := range opts {
+		opt(call)
+	}
+	return call
+}
+
+// pinHead appends AtBlock with the head block number, unless block is already set.
+func (c *Client) pinHead(ctx context.Context, opts []ReadOption) ([]ReadOption, error) {
+	if c.callOpts(ctx, opts).BlockNumber != nil {
+		return opts, nil
+	}
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts[:len(opts):len(opts)], AtBlock(header.Number)), nil
+}
@@ -0,0 +1,317 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PendingTx is a handle for broadcasted staking transaction. Hash can be persisted by the caller
+// and waiting can be resumed later with a handle returned by Client.AttachTx.
+type PendingTx struct {
+	Kind TxKind
+	// Hash of the originally broadcasted transaction.
+	Hash common.Hash
+	// Nonce of the transaction. Unknown (0) for attached transactions.
+	Nonce uint64
+
+	client *Client
+	cfg    txConfig
+	// signer is nil for attached transactions.
+	signer Signer
+	from   common.Address
+	// journaled is true if transaction was recorded in the client journal.
+	journaled bool
+	created   time.Time
+	// desc is used to annotate ErrTransactionReverted.
+	desc string
+
+	mu sync.Mutex
+	// sent are all versions of the transaction with the same nonce, latest is the last.
+	sent []sentTx
+}
+
+type sentTx struct {
+	hash common.Hash
+	// tx is nil for attached transactions.
+	tx     *types.Transaction
+	cancel bool
+}
+
+// Hashes returns hashes of the original transaction and all replacements, in order of broadcasting.
+// Any of them may be mined.
+func (tx *PendingTx) Hashes() []common.Hash {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	hashes := make([]common.Hash, len(tx.sent))
+	for i := range tx.sent {
+		hashes[i] = tx.sent[i].hash
+	}
+	return hashes
+}
+
+func (tx *PendingTx) latest() sentTx {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	return tx.sent[len(tx.sent)-1]
+}
+
+func (tx *PendingTx) replace(replacement sentTx) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.sent = append(tx.sent, replacement)
+}
+
+// journalEntry returns entry with all versions of the transaction and a replacement that is about to be sent.
+func (tx *PendingTx) journalEntry(replacement sentTx) JournalEntry {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	entry := JournalEntry{
+		Kind:          tx.Kind,
+		From:          tx.from,
+		Nonce:         tx.Nonce,
+		Confirmations: tx.cfg.confirmations,
+		Created:       tx.created,
+	}
+	for _, sent := range append(tx.sent[:len(tx.sent):len(tx.sent)], replacement) {
+		entry.Hashes = append(entry.Hashes, sent.hash)
+		if sent.cancel {
+			entry.Cancels = append(entry.Cancels, sent.hash)
+		}
+	}
+	return entry
+}
+
+// forget removes transaction from the journal once its outcome is known.
+func (tx *PendingTx) forget() {
+	if tx.journaled && tx.client.journal != nil {
+		// failure to delete is not fatal, outcome will be reported again by Resume.
+		_ = tx.client.journal.Delete(tx.from, tx.Nonce)
+	}
+}
+
+func (tx *PendingTx) find(hash common.Hash) (sentTx, bool) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	for i := range tx.sent {
+		if tx.sent[i].hash == hash {
+			return tx.sent[i], true
+		}
+	}
+	return sentTx{}, false
+}
+
+// Wait blocks until transaction is mined or context is done. If transaction was mined
+// with failed status ErrTransactionReverted is returned together with result.
+// If transaction was cancelled ErrTransactionCancelled is returned.
+func (tx *PendingTx) Wait(ctx context.Context) (TxResult, error) {
+	_, result, err := tx.wait(ctx)
+	return result, err
+}
+
+func (tx *PendingTx) wait(ctx context.Context) (*types.Receipt, TxResult, error) {
+	var result TxResult
+	receipt, err := tx.waitConfirmed(ctx)
+	if errors.Is(err, ErrTransactionDropped) {
+		tx.forget()
+	}
+	if err != nil {
+		return nil, result, err
+	}
+	tx.forget()
+	mined, _ := tx.find(receipt.TxHash)
+	if mined.tx == nil {
+		mined.tx, _, err = tx.client.client.TransactionByHash(ctx, receipt.TxHash)
+		if err != nil {
+			return nil, result, err
+		}
+	}
+	result = TxResult{
+		Hash:        receipt.TxHash,
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		BlockHash:   receipt.BlockHash,
+		GasUsed:     receipt.GasUsed,
+		GasPrice:    mined.tx.GasPrice(),
+		Fee:         new(big.Int).Mul(mined.tx.GasPrice(), new(big.Int).SetUint64(receipt.GasUsed)),
+	}
+	if mined.cancel {
+		return receipt, result, fmt.Errorf("%w: %s", ErrTransactionCancelled, tx.desc)
+	}
+	result.Events, err = tx.client.parseEvents(receipt)
+	if err != nil {
+		return receipt, result, err
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return receipt, result, fmt.Errorf("%w: %s", ErrTransactionReverted, tx.desc)
+	}
+	return receipt, result, nil
+}
+
+// waitMined polls for receipt of any version of the transaction until it is available. Errors,
+// including ethereum.NotFound, are not fatal and polling continues until context is done.
+// If client has BumpPolicy transaction is replaced with higher gas price every policy interval.
+func (tx *PendingTx) waitMined(ctx context.Context) (*types.Receipt, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	var (
+		policy   = tx.client.bumpPolicy
+		bumped   = time.Now()
+		bumpable = policy != nil && tx.signer != nil
+	)
+	for {
+		for _, hash := range tx.Hashes() {
+			receipt, err := tx.client.client.TransactionReceipt(ctx, hash)
+			if err == nil && receipt != nil {
+				if receipt.Status == types.ReceiptStatusFailed {
+					tx.notifyReceipt(TxReverted, receipt)
+				} else {
+					tx.notifyReceipt(TxMined, receipt)
+				}
+				return receipt, nil
+			}
+		}
+		if bumpable && time.Since(bumped) >= policy.Interval {
+			bumped = time.Now()
+			// error is not fatal. previous version may be mined or replacement may be retried later.
+			if err := tx.client.bump(ctx, tx, policy); err == errMaxGasPrice {
+				bumpable = false
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitConfirmed waits until receipt has required number of confirmations and block with transaction
+// is still canonical. If transaction was reorganized it is either tracked again or ErrTransactionDropped
+// is returned if node doesn't know about it anymore. Canonical chain is checked at most once per tick.
+func (tx *PendingTx) waitConfirmed(ctx context.Context) (*types.Receipt, error) {
+	receipt, err := tx.waitMined(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tx.cfg.confirmations <= 1 {
+		tx.notifyReceipt(TxConfirmed, receipt)
+		return receipt, nil
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		target := new(big.Int).SetUint64(receipt.BlockNumber.Uint64() + tx.cfg.confirmations - 1)
+		head, err := tx.client.client.HeaderByNumber(ctx, nil)
+		if err == nil && head.Number.Cmp(target) >= 0 {
+			header, err := tx.client.client.HeaderByNumber(ctx, receipt.BlockNumber)
+			if err == nil && header != nil && header.Hash() == receipt.BlockHash {
+				tx.notifyReceipt(TxConfirmed, receipt)
+				return receipt, nil
+			}
+			if err == nil {
+				receipt, err = tx.afterReorg(ctx, receipt)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// afterReorg returns receipt of any version of the transaction that was included into another block after
+// reorganization. If none of them is known to be mined yet reorged receipt is returned, so that it is checked
+// again on the next tick. ErrTransactionDropped is returned if node doesn't know about any version.
+func (tx *PendingTx) afterReorg(ctx context.Context, reorged *types.Receipt) (*types.Receipt, error) {
+	for _, hash := range tx.Hashes() {
+		receipt, err := tx.client.client.TransactionReceipt(ctx, hash)
+		if err == nil && receipt != nil && receipt.BlockHash != reorged.BlockHash {
+			return receipt, nil
+		}
+	}
+	if tx.unknown(ctx) {
+		return nil, fmt.Errorf("%w: 0x%x was mined in block 0x%x",
+			ErrTransactionDropped, reorged.TxHash, reorged.BlockHash)
+	}
+	return reorged, nil
+}
+
+// unknown is true if node doesn't know about any version of the transaction. Transient errors are
+// not treated as unknown.
+func (tx *PendingTx) unknown(ctx context.Context) bool {
+	for _, hash := range tx.Hashes() {
+		if _, _, err := tx.client.client.TransactionByHash(ctx, hash); !errors.Is(err, ethereum.NotFound) {
+			return false
+		}
+	}
+	return true
+}
+
+// PendingWithdrawal is a handle for broadcasted RequestUnbonding or WithdrawAllPending transaction.
+type PendingWithdrawal struct {
+	*PendingTx
+}
+
+// Wait blocks until transaction is mined and returns withdrawal info parsed from receipt logs.
+func (tx *PendingWithdrawal) Wait(ctx context.Context) (info WithdrawalInfo, err error) {
+	receipt, result, err := tx.PendingTx.wait(ctx)
+	info.Tx = result
+	if err != nil {
+		return info, err
+	}
+	if tx.Kind == TxRequestWithdrawal {
+		info, err = tx.client.parseRequestWithdrawal(receipt)
+	} else {
+		info, err = tx.client.parseCompleteWithdrawals(receipt)
+	}
+	info.Tx = result
+	return info, err
+}
+
+// AttachTx returns handle for previously broadcasted transaction.
+func (c *Client) AttachTx(kind TxKind, hash common.Hash, opts ...TxOption) *PendingTx {
+	return &PendingTx{
+		Kind:   kind,
+		Hash:   hash,
+		client: c,
+		cfg:    c.newTxConfig(opts),
+		desc:   fmt.Sprintf("%v 0x%x", kind, hash),
+		sent:   []sentTx{{hash: hash}},
+	}
+}
+
+// AttachWithdrawal returns handle for previously broadcasted TxRequestWithdrawal or TxCompleteWithdrawals
+// transaction.
+func (c *Client) AttachWithdrawal(kind TxKind, hash common.Hash, opts ...TxOption) *PendingWithdrawal {
+	return &PendingWithdrawal{PendingTx: c.AttachTx(kind, hash, opts...)}
+}
+
+// newPendingTx returns handle for transaction sent from the address. Signer is nil if transaction
+// was signed outside of the client.
+func (c *Client) newPendingTx(kind TxKind, from common.Address, signer Signer, tx *types.Transaction,
+	cfg txConfig, desc string) *PendingTx {
+	return &PendingTx{
+		Kind:      kind,
+		Hash:      tx.Hash(),
+		Nonce:     tx.Nonce(),
+		client:    c,
+		cfg:       cfg,
+		from:      from,
+		journaled: c.journal != nil,
+		created:   time.Now(),
+		signer:    signer,
+		desc:      desc,
+		sent:      []sentTx{{hash: tx.Hash(), tx: tx}},
+	}
+}
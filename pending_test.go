@@ -0,0 +1,115 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// reorgBackend serves receipts that are never updated after reorganization, e.g. by a lagging receipt index.
+type reorgBackend struct {
+	ETHBackend
+
+	mu        sync.Mutex
+	calls     int
+	head      int64
+	canonical map[int64]common.Hash
+	receipts  map[common.Hash]*types.Receipt
+	known     map[common.Hash]bool
+}
+
+func (b *reorgBackend) call() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls++
+}
+
+func (b *reorgBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	b.call()
+	if number == nil {
+		return &types.Header{Number: big.NewInt(b.head)}, nil
+	}
+	// header hash is unique for the extra data, canonical hash is returned by its number.
+	header := &types.Header{Number: number, Extra: b.canonical[number.Int64()].Bytes()}
+	return header, nil
+}
+
+func (b *reorgBackend) blockHash(number int64) common.Hash {
+	header, _ := b.HeaderByNumber(context.Background(), big.NewInt(number))
+	return header.Hash()
+}
+
+func (b *reorgBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	b.call()
+	receipt, exist := b.receipts[hash]
+	if !exist {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (b *reorgBackend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	b.call()
+	if !b.known[hash] {
+		return nil, false, ethereum.NotFound
+	}
+	return new(types.Transaction), false, nil
+}
+
+func TestWaitAfterReorg(t *testing.T) {
+	original, replacement := common.Hash{1}, common.Hash{2}
+	for _, tc := range []struct {
+		desc string
+		// remined is true if replacement is included into canonical block after reorg.
+		remined bool
+		known   bool
+		err     error
+	}{
+		{desc: "replacement remined", remined: true, known: true},
+		{desc: "pending", known: true, err: context.DeadlineExceeded},
+		{desc: "dropped", err: ErrTransactionDropped},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			backend := &reorgBackend{
+				head:      10,
+				canonical: map[int64]common.Hash{5: {0xa}, 6: {0xb}},
+				known:     map[common.Hash]bool{original: tc.known, replacement: tc.known},
+			}
+			// stale receipt points to the block that is no longer canonical.
+			backend.receipts = map[common.Hash]*types.Receipt{
+				original: {TxHash: original, BlockNumber: big.NewInt(5), BlockHash: common.Hash{0xff}},
+			}
+			if tc.remined {
+				backend.receipts[replacement] = &types.Receipt{
+					TxHash: replacement, BlockNumber: big.NewInt(6), BlockHash: backend.blockHash(6),
+				}
+			}
+			tx := &PendingTx{
+				client: &Client{client: backend},
+				cfg:    txConfig{confirmations: 2},
+				sent:   []sentTx{{hash: original}, {hash: replacement}},
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+			defer cancel()
+
+			receipt, err := tx.waitConfirmed(ctx)
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), "unexpected error %v", err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, replacement, receipt.TxHash)
+			}
+			backend.mu.Lock()
+			defer backend.mu.Unlock()
+			require.Less(t, backend.calls, 50)
+		})
+	}
+}
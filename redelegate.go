@@ -0,0 +1,175 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:generate stringer -type=RedelegationStage
+type RedelegationStage uint8
+
+const (
+	// RedelegationStarted nothing was broadcasted yet.
+	RedelegationStarted RedelegationStage = iota
+	// RedelegationWithdrawalRequested unbonding request was broadcasted.
+	RedelegationWithdrawalRequested
+	// RedelegationUnbonding withdrawal can be completed after ReadinessTimestamp.
+	RedelegationUnbonding
+	// RedelegationWithdrawalCompleting completion of pending withdrawals was broadcasted.
+	RedelegationWithdrawalCompleting
+	// RedelegationWithdrawn stake was returned to the delegator.
+	RedelegationWithdrawn
+	// RedelegationDelegating delegation to the new transcoder was broadcasted.
+	RedelegationDelegating
+	// RedelegationDone stake is delegated to the new transcoder.
+	RedelegationDone
+)
+
+// Redelegation is a progress of moving stake from one transcoder to another. It can be persisted by the caller
+// and passed to Client.Redelegate again to resume after restart.
+type Redelegation struct {
+	From   common.Address    `json:"from"`
+	To     common.Address    `json:"to"`
+	Amount *big.Int          `json:"amount"`
+	Stage  RedelegationStage `json:"stage"`
+	// Tx is a hash of the transaction broadcasted on the current stage.
+	Tx                 common.Hash `json:"tx,omitempty"`
+	ReadinessTimestamp uint64      `json:"readinessTimestamp,omitempty"`
+	// Withdrawn is an amount returned by withdrawal. If other pending withdrawals of the delegator were completed
+	// together with this one it is larger than Amount, but only Amount is delegated to the new transcoder.
+	Withdrawn *big.Int `json:"withdrawn,omitempty"`
+}
+
+// failed returns stage to the one before transaction was broadcasted, if transaction failed, so that it
+// will be broadcasted again on resume.
+func (r *Redelegation) failed(stage RedelegationStage, err error) error {
+	if errors.Is(err, ErrTransactionReverted) ||
+		errors.Is(err, ErrTransactionCancelled) ||
+		errors.Is(err, ErrTransactionDropped) {
+		r.Stage = stage
+		r.Tx = common.Hash{}
+	}
+	return err
+}
+
+// Redelegate requests withdrawal of the amount from one transcoder, waits for unbonding period if transcoder
+// is bonded, completes withdrawal and delegates amount to another transcoder. Progress is called with
+// the copy of r after every stage change. If error is returned r can be passed to Redelegate again to resume.
+func (c *Client) Redelegate(ctx context.Context,
+	signer Signer,
+	r *Redelegation,
+	progress func(Redelegation),
+	opts ...TxOption) error {
+	var (
+		pending *PendingTx
+		err     error
+	)
+	for r.Stage != RedelegationDone {
+		stage := r.Stage
+		pending, err = c.redelegateStage(ctx, signer, r, pending, opts)
+		if r.Stage != stage && progress != nil {
+			progress(*r)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redelegateStage executes current stage and returns handle for the transaction if it was broadcasted.
+// Handle of the previous stage is used for waiting, unless stage was restored from persisted state.
+func (c *Client) redelegateStage(ctx context.Context, signer Signer, r *Redelegation, pending *PendingTx,
+	opts []TxOption) (*PendingTx, error) {
+	attach := func(kind TxKind) *PendingTx {
+		if pending != nil && pending.Hash == r.Tx {
+			return pending
+		}
+		return c.AttachTx(kind, r.Tx, opts...)
+	}
+	switch r.Stage {
+	case RedelegationStarted:
+		if r.Amount == nil {
+			return nil, fmt.Errorf("redelegation from 0x%x to 0x%x doesn't have amount", r.From, r.To)
+		}
+		// stake must not be withdrawn if it can't be delegated to the new transcoder.
+		if err := c.checkDelegateTarget(ctx, r.To, r.Amount); err != nil {
+			return nil, err
+		}
+		tx, err := c.SubmitRequestWithdrawal(ctx, signer, r.From, r.Amount, opts...)
+		if err != nil {
+			return nil, err
+		}
+		r.Tx, r.Stage = tx.Hash, RedelegationWithdrawalRequested
+		return tx.PendingTx, nil
+	case RedelegationWithdrawalRequested:
+		info, err := (&PendingWithdrawal{PendingTx: attach(TxRequestWithdrawal)}).Wait(ctx)
+		if err != nil {
+			return nil, r.failed(RedelegationStarted, err)
+		}
+		// withdrawal is completed immediatly if transcoder is not bonded
+		if info.Amount != nil {
+			r.Withdrawn, r.Stage = info.Amount, RedelegationWithdrawn
+		} else {
+			r.ReadinessTimestamp, r.Stage = info.ReadinessTimestamp, RedelegationUnbonding
+		}
+	case RedelegationUnbonding:
+		if err := c.waitTimestamp(ctx, r.ReadinessTimestamp); err != nil {
+			return nil, err
+		}
+		tx, err := c.SubmitCompleteWithdrawals(ctx, signer, opts...)
+		if err != nil {
+			return nil, err
+		}
+		r.Tx, r.Stage = tx.Hash, RedelegationWithdrawalCompleting
+		return tx.PendingTx, nil
+	case RedelegationWithdrawalCompleting:
+		info, err := (&PendingWithdrawal{PendingTx: attach(TxCompleteWithdrawals)}).Wait(ctx)
+		if err != nil {
+			return nil, r.failed(RedelegationUnbonding, err)
+		}
+		r.Withdrawn, r.Stage = info.Amount, RedelegationWithdrawn
+	case RedelegationWithdrawn:
+		if r.Withdrawn == nil || r.Withdrawn.Sign() == 0 {
+			return nil, fmt.Errorf("redelegation from 0x%x to 0x%x doesn't have withdrawn amount", r.From, r.To)
+		}
+		amount := r.Amount
+		if r.Withdrawn.Cmp(amount) < 0 {
+			amount = r.Withdrawn
+		}
+		tx, err := c.SubmitDelegate(ctx, signer, r.To, amount, opts...)
+		if err != nil {
+			return nil, err
+		}
+		r.Tx, r.Stage = tx.Hash, RedelegationDelegating
+		return tx, nil
+	case RedelegationDelegating:
+		if _, err := attach(TxDelegate).Wait(ctx); err != nil {
+			return nil, r.failed(RedelegationWithdrawn, err)
+		}
+		r.Tx, r.Stage = common.Hash{}, RedelegationDone
+	}
+	return nil, nil
+}
+
+// waitTimestamp waits until head block has timestamp not lower than ts.
+func (c *Client) waitTimestamp(ctx context.Context, ts uint64) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		head, err := c.HeadTimestamp(ctx)
+		if err == nil && head >= ts {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// DefaultPriceBump is a minimal gas price increase, in percents, that go-ethereum transaction pool
+// accepts for replacement of the pending transaction.
+const DefaultPriceBump = 10
+
+var errMaxGasPrice = errors.New("max gas price reached")
+
+// BumpPolicy configures automatic replacement of transactions that are not mined in time.
+type BumpPolicy struct {
+	// Interval after which transaction is replaced with the one with higher gas price.
+	Interval time.Duration
+	// Percent of the gas price increase. DefaultPriceBump is used if it is lower than that.
+	Percent uint64
+	// MaxGasPrice transaction will not be replaced with gas price higher than this value. Nil is no limit.
+	MaxGasPrice *big.Int
+}
+
+func bumpGasPrice(price *big.Int, percent uint64) *big.Int {
+	if percent < DefaultPriceBump {
+		percent = DefaultPriceBump
+	}
+	bumped := new(big.Int).Mul(price, new(big.Int).SetUint64(100+percent))
+	bumped = bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(price) <= 0 {
+		bumped = bumped.Add(price, one)
+	}
+	return bumped
+}
+
+// SpeedUp replaces pending transaction with the same transaction signed with higher gas price. If gasPrice
+// is nil gas price of the latest version is increased by DefaultPriceBump. Any version of the transaction
+// can be mined after replacement, tx.Wait tracks all of them.
+func (c *Client) SpeedUp(ctx context.Context, signer Signer, tx *PendingTx, gasPrice *big.Int) error {
+	latest, err := c.latestPending(ctx, signer, tx)
+	if err != nil {
+		return err
+	}
+	if latest.cancel {
+		return fmt.Errorf("transaction 0x%x is already cancelled", tx.Hash)
+	}
+	if gasPrice == nil {
+		gasPrice = bumpGasPrice(latest.tx.GasPrice(), DefaultPriceBump)
+	}
+	return c.sendReplacement(ctx, signer, tx, withGasPrice(latest.tx, gasPrice), false)
+}
+
+// Cancel replaces pending transaction with a zero-value transfer to the sender itself. If gasPrice is nil
+// gas price of the latest version is increased by DefaultPriceBump. If cancellation is mined tx.Wait
+// returns ErrTransactionCancelled.
+func (c *Client) Cancel(ctx context.Context, signer Signer, tx *PendingTx, gasPrice *big.Int) error {
+	latest, err := c.latestPending(ctx, signer, tx)
+	if err != nil {
+		return err
+	}
+	if gasPrice == nil {
+		gasPrice = bumpGasPrice(latest.tx.GasPrice(), DefaultPriceBump)
+	}
+	cancel := types.NewTransaction(latest.tx.Nonce(), signer.Address(), new(big.Int), params.TxGas, gasPrice, nil)
+	return c.sendReplacement(ctx, signer, tx, cancel, true)
+}
+
+// bump replaces latest version of the transaction according to the policy.
+func (c *Client) bump(ctx context.Context, tx *PendingTx, policy *BumpPolicy) error {
+	latest, err := c.latestPending(ctx, tx.signer, tx)
+	if err != nil {
+		return err
+	}
+	gasPrice := bumpGasPrice(latest.tx.GasPrice(), policy.Percent)
+	if policy.MaxGasPrice != nil && gasPrice.Cmp(policy.MaxGasPrice) > 0 {
+		return errMaxGasPrice
+	}
+	return c.sendReplacement(ctx, tx.signer, tx, withGasPrice(latest.tx, gasPrice), latest.cancel)
+}
+
+// latestPending returns latest version of the transaction and ensures that it is still pending
+// and was sent by the signer.
+func (c *Client) latestPending(ctx context.Context, signer Signer, tx *PendingTx) (latest sentTx, err error) {
+	latest = tx.latest()
+	fetched, pending, err := c.client.TransactionByHash(ctx, latest.hash)
+	if err != nil {
+		return latest, err
+	}
+	if !pending {
+		return latest, fmt.Errorf("%w: 0x%x", ErrTransactionNotPending, latest.hash)
+	}
+	if latest.tx == nil {
+		latest.tx = fetched
+	}
+	sender, err := types.Sender(types.NewEIP155Signer(latest.tx.ChainId()), latest.tx)
+	if err != nil {
+		return latest, err
+	}
+	if sender != signer.Address() {
+		return latest, fmt.Errorf("transaction 0x%x was sent by 0x%x, can't be replaced by 0x%x",
+			latest.hash, sender, signer.Address())
+	}
+	return latest, nil
+}
+
+// withGasPrice returns unsigned copy of the transaction with different gas price.
+func withGasPrice(tx *types.Transaction, gasPrice *big.Int) *types.Transaction {
+	return types.NewTransaction(tx.Nonce(), *tx.To(), tx.Value(), tx.Gas(), gasPrice, tx.Data())
+}
+
+func (c *Client) sendReplacement(ctx context.Context, signer Signer, tx *PendingTx, replacement *types.Transaction, cancel bool) error {
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	signed, err := c.sign(tx.Kind, signer, replacement, chainID)
+	if err != nil {
+		return err
+	}
+	sent := sentTx{hash: signed.Hash(), tx: signed, cancel: cancel}
+	if tx.journaled {
+		if err := c.journal.Put(tx.journalEntry(sent)); err != nil {
+			return err
+		}
+	}
+	if err := c.client.SendTransaction(ctx, signed); err != nil {
+		return err
+	}
+	c.notifyTx(TxBroadcasted, tx.Kind, signer.Address(), signed)
+	tx.replace(sent)
+	return nil
+}
@@ -0,0 +1,162 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpGasPrice(t *testing.T) {
+	for _, tc := range []struct {
+		price, expected int64
+		percent         uint64
+	}{
+		{price: 100, percent: 0, expected: 110},
+		{price: 100, percent: 25, expected: 125},
+		{price: 1, percent: 10, expected: 2},
+		{price: 0, percent: 10, expected: 1},
+	} {
+		bumped := bumpGasPrice(big.NewInt(tc.price), tc.percent)
+		require.Equal(t, tc.expected, bumped.Int64())
+	}
+}
+
+// poolBackend keeps sent transactions pending until they are mined with mine.
+type poolBackend struct {
+	ETHBackend
+
+	mu       sync.Mutex
+	sent     []*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+}
+
+func (b *poolBackend) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sent = append(b.sent, tx)
+	return nil
+}
+
+func (b *poolBackend) TransactionByHash(_ context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, tx := range b.sent {
+		if tx.Hash() == hash {
+			_, mined := b.receipts[hash]
+			return tx, !mined, nil
+		}
+	}
+	return nil, false, ethereum.NotFound
+}
+
+func (b *poolBackend) TransactionReceipt(_ context.Context, hash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	receipt, exist := b.receipts[hash]
+	if !exist {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (b *poolBackend) mine(tx *types.Transaction) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.receipts[tx.Hash()] = &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      tx.Hash(),
+		BlockNumber: big.NewInt(1),
+		BlockHash:   common.Hash{1},
+		GasUsed:     tx.Gas(),
+	}
+}
+
+func (b *poolBackend) transactions() []*types.Transaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*types.Transaction(nil), b.sent...)
+}
+
+// sendPending broadcasts delegation with nonce 5 and gas price 10 through the backend.
+func sendPending(t *testing.T, opts ...ClientOption) (*Client, *poolBackend, Signer, *PendingTx) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := NewKeySigner(key)
+	backend := &poolBackend{receipts: map[common.Hash]*types.Receipt{}}
+	client, err := NewClient(backend, common.Address{1}, append(opts, WithChainID(big.NewInt(1)))...)
+	require.NoError(t, err)
+
+	unsigned := types.NewTransaction(5, client.address, big.NewInt(50), 100000, big.NewInt(10), []byte{1})
+	tx, err := signer.SignTx(unsigned, big.NewInt(1))
+	require.NoError(t, err)
+	require.NoError(t, backend.SendTransaction(context.Background(), tx))
+	return client, backend, signer, client.newPendingTx(TxDelegate, signer.Address(), signer, tx, txConfig{}, "delegation")
+}
+
+func TestSpeedUp(t *testing.T) {
+	client, backend, signer, pending := sendPending(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, client.SpeedUp(ctx, signer, pending, nil))
+	sent := backend.transactions()
+	require.Len(t, sent, 2)
+	require.Equal(t, uint64(5), sent[1].Nonce())
+	require.Equal(t, int64(11), sent[1].GasPrice().Int64())
+	require.Equal(t, sent[0].Data(), sent[1].Data())
+	require.Equal(t, sent[0].Value(), sent[1].Value())
+	require.Equal(t, []common.Hash{sent[0].Hash(), sent[1].Hash()}, pending.Hashes())
+
+	backend.mine(sent[1])
+	result, err := pending.Wait(ctx)
+	require.NoError(t, err)
+	require.Equal(t, sent[1].Hash(), result.Hash)
+	require.Equal(t, int64(11), result.GasPrice.Int64())
+
+	// mined transaction can't be replaced
+	require.True(t, errors.Is(client.SpeedUp(ctx, signer, pending, nil), ErrTransactionNotPending))
+}
+
+func TestCancel(t *testing.T) {
+	client, backend, signer, pending := sendPending(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, client.Cancel(ctx, signer, pending, big.NewInt(20)))
+	sent := backend.transactions()
+	require.Len(t, sent, 2)
+	cancellation := sent[1]
+	require.Equal(t, uint64(5), cancellation.Nonce())
+	require.Equal(t, signer.Address(), *cancellation.To())
+	require.Zero(t, cancellation.Value().Sign())
+	require.Equal(t, int64(20), cancellation.GasPrice().Int64())
+
+	backend.mine(cancellation)
+	_, err := pending.Wait(ctx)
+	require.True(t, errors.Is(err, ErrTransactionCancelled))
+}
+
+func TestBumpMaxGasPrice(t *testing.T) {
+	_, backend, _, pending := sendPending(t, WithBumpPolicy(BumpPolicy{
+		Interval:    time.Millisecond,
+		MaxGasPrice: big.NewInt(11),
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 2500*time.Millisecond)
+	defer cancel()
+
+	_, err := pending.Wait(ctx)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	sent := backend.transactions()
+	require.Len(t, sent, 2)
+	require.Equal(t, uint64(5), sent[1].Nonce())
+	require.Equal(t, int64(11), sent[1].GasPrice().Int64())
+}
@@ -0,0 +1,184 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RetryPolicy configures retries of the backend requests that failed with transient errors.
+// Transactions are never broadcasted again, as failed broadcast may have reached the node.
+type RetryPolicy struct {
+	// MaxAttempts including the first one. Request is not retried if it is lower than 2.
+	MaxAttempts int
+	// Backoff before the first retry, doubled after every attempt.
+	Backoff time.Duration
+	// MaxBackoff limits backoff between attempts. Zero is no limit.
+	MaxBackoff time.Duration
+	// Retryable classifies errors. DefaultRetryable is used if nil.
+	Retryable func(error) bool
+}
+
+// DefaultRetryable treats transport failures as transient. Errors returned by the node, like revert of
+// the call, and ethereum.NotFound are not retried.
+func DefaultRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ethereum.NotFound) ||
+		errors.Is(err, bind.ErrNoCode) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// retryBackend retries read requests according to the policy.
+type retryBackend struct {
+	ETHBackend
+	policy RetryPolicy
+}
+
+func newRetryBackend(backend ETHBackend, policy RetryPolicy) *retryBackend {
+	if policy.Retryable == nil {
+		policy.Retryable = DefaultRetryable
+	}
+	return &retryBackend{ETHBackend: backend, policy: policy}
+}
+
+func (b *retryBackend) do(ctx context.Context, request func() error) error {
+	return retry(ctx, b.policy, request)
+}
+
+// retry repeats request until it succeeds, fails with error that is not retryable or attempts are exhausted.
+func retry(ctx context.Context, policy RetryPolicy, request func() error) error {
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = DefaultRetryable
+	}
+	backoff := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err := request()
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(err) {
+			return err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+		if policy.MaxBackoff != 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+func (b *retryBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = b.do(ctx, func() error {
+		code, err = b.ETHBackend.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (b *retryBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (out []byte, err error) {
+	err = b.do(ctx, func() error {
+		out, err = b.ETHBackend.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return out, err
+}
+
+func (b *retryBackend) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = b.do(ctx, func() error {
+		code, err = b.ETHBackend.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+func (b *retryBackend) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = b.do(ctx, func() error {
+		nonce, err = b.ETHBackend.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+func (b *retryBackend) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = b.do(ctx, func() error {
+		price, err = b.ETHBackend.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+func (b *retryBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = b.do(ctx, func() error {
+		gas, err = b.ETHBackend.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+func (b *retryBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = b.do(ctx, func() error {
+		logs, err = b.ETHBackend.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+func (b *retryBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (receipt *types.Receipt, err error) {
+	err = b.do(ctx, func() error {
+		receipt, err = b.ETHBackend.TransactionReceipt(ctx, hash)
+		return err
+	})
+	return receipt, err
+}
+
+func (b *retryBackend) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = b.do(ctx, func() error {
+		header, err = b.ETHBackend.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+func (b *retryBackend) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, pending bool, err error) {
+	err = b.do(ctx, func() error {
+		tx, pending, err = b.ETHBackend.TransactionByHash(ctx, hash)
+		return err
+	})
+	return tx, pending, err
+}
+
+func (b *retryBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = b.do(ctx, func() error {
+		balance, err = b.ETHBackend.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+// ChainID returns nil if wrapped backend doesn't report chain id.
+func (b *retryBackend) ChainID(ctx context.Context) (chainID *big.Int, err error) {
+	reader, ok := b.ETHBackend.(chainIDReader)
+	if !ok {
+		return nil, nil
+	}
+	err = b.do(ctx, func() error {
+		chainID, err = reader.ChainID(ctx)
+		return err
+	})
+	return chainID, err
+}
@@ -0,0 +1,56 @@
+package staking
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type flakyBackend struct {
+	ETHBackend
+	failures int
+	headers  int
+	sent     int
+}
+
+func (b *flakyBackend) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	b.headers++
+	if b.headers <= b.failures {
+		return nil, errors.New("connection reset by peer")
+	}
+	return &types.Header{Number: big.NewInt(1)}, nil
+}
+
+func (b *flakyBackend) SendTransaction(context.Context, *types.Transaction) error {
+	b.sent++
+	return errors.New("connection reset by peer")
+}
+
+func TestRetryBackend(t *testing.T) {
+	flaky := &flakyBackend{failures: 2}
+	backend := newRetryBackend(flaky, RetryPolicy{MaxAttempts: 3})
+
+	header, err := backend.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), header.Number.Int64())
+	require.Equal(t, 3, flaky.headers)
+
+	flaky.headers, flaky.failures = 0, 5
+	_, err = backend.HeaderByNumber(context.Background(), nil)
+	require.Error(t, err)
+	require.Equal(t, 3, flaky.headers)
+
+	require.Error(t, backend.SendTransaction(context.Background(), new(types.Transaction)))
+	require.Equal(t, 1, flaky.sent)
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	require.True(t, DefaultRetryable(errors.New("connection refused")))
+	require.False(t, DefaultRetryable(ethereum.NotFound))
+	require.False(t, DefaultRetryable(context.Canceled))
+}
@@ -0,0 +1,114 @@
+package staking
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer holds custody of a single account and signs transactions on its behalf.
+type Signer interface {
+	// Address of the account that is used as a sender of signed transactions.
+	Address() common.Address
+	// SignTx signs transaction. If chainID is nil transaction is signed without replay protection.
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+func txSigner(chainID *big.Int) types.Signer {
+	if chainID == nil {
+		return types.HomesteadSigner{}
+	}
+	return types.NewEIP155Signer(chainID)
+}
+
+// NewKeySigner returns Signer that keeps private key in memory.
+func NewKeySigner(key *ecdsa.PrivateKey) Signer {
+	return &keySigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+type keySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+func (s *keySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *keySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, txSigner(chainID), s.key)
+}
+
+// PassphraseFunc returns passphrase of the keystore account, e.g. prompted from the operator or read
+// from a secret store.
+type PassphraseFunc func(accounts.Account) (string, error)
+
+// NewKeystoreSigner returns Signer for the account stored in go-ethereum keystore. Passphrase is requested
+// for every signature and neither passphrase nor decrypted key are retained by the signer. Long-running
+// services should prefer NewWalletSigner over a hardware wallet or an unlocked keystore wallet.
+func NewKeystoreSigner(ks *keystore.KeyStore, account accounts.Account, passphrase PassphraseFunc) Signer {
+	return &keystoreSigner{
+		ks:         ks,
+		account:    account,
+		passphrase: passphrase,
+	}
+}
+
+type keystoreSigner struct {
+	ks         *keystore.KeyStore
+	account    accounts.Account
+	passphrase PassphraseFunc
+}
+
+func (s *keystoreSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *keystoreSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	passphrase, err := s.passphrase(s.account)
+	if err != nil {
+		return nil, err
+	}
+	return s.ks.SignTxWithPassphrase(s.account, passphrase, tx, chainID)
+}
+
+// NewWalletSigner returns Signer that delegates signing to the wallet, e.g. hardware wallet
+// or unlocked keystore wallet.
+func NewWalletSigner(wallet accounts.Wallet, account accounts.Account) Signer {
+	return &walletSigner{
+		wallet:  wallet,
+		account: account,
+	}
+}
+
+type walletSigner struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+}
+
+func (s *walletSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *walletSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.wallet.SignTx(s.account, tx, chainID)
+}
+
+// NewExternalSigner connects to external signer (clef) on the endpoint and returns Signer
+// for the address managed by it.
+func NewExternalSigner(endpoint string, address common.Address) (Signer, error) {
+	ext, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return NewWalletSigner(ext, accounts.Account{Address: address}), nil
+}
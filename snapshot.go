@@ -0,0 +1,73 @@
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StakingSnapshot is a state of the staking contract at a single block.
+type StakingSnapshot struct {
+	BlockNumber uint64
+	BlockHash   common.Hash
+	// Timestamp of the block.
+	Timestamp uint64
+
+	UnbondingPeriod *big.Int
+	MinDelegation   *big.Int
+	MinSelfStake    *big.Int
+
+	Transcoders []Transcoder
+	// TotalStake is a sum of TotalStake of all transcoders.
+	TotalStake *big.Int
+	// SelfStake is a sum of SelfStake of all transcoders.
+	SelfStake *big.Int
+	// DelegatedStake is a sum of DelegatedStake of all transcoders.
+	DelegatedStake *big.Int
+	// BondedStake is a sum of TotalStake of transcoders in StateBonded.
+	BondedStake *big.Int
+}
+
+// Snapshot reads parameters of the contract and all transcoders at the block. Nil block is the head block.
+func (c *Client) Snapshot(ctx context.Context, block *big.Int) (*StakingSnapshot, error) {
+	header, err := c.client.HeaderByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	at := AtBlock(header.Number)
+	snapshot := &StakingSnapshot{
+		BlockNumber:    header.Number.Uint64(),
+		BlockHash:      header.Hash(),
+		Timestamp:      header.Time,
+		TotalStake:     new(big.Int),
+		SelfStake:      new(big.Int),
+		DelegatedStake: new(big.Int),
+		BondedStake:    new(big.Int),
+	}
+	snapshot.UnbondingPeriod, err = c.GetUnbondingPeriod(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.MinDelegation, err = c.GetMinDelegation(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.MinSelfStake, err = c.GetRequiredSelfStake(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Transcoders, err = c.GetAllTranscoders(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	for _, tcr := range snapshot.Transcoders {
+		snapshot.TotalStake.Add(snapshot.TotalStake, tcr.TotalStake)
+		snapshot.SelfStake.Add(snapshot.SelfStake, tcr.SelfStake)
+		snapshot.DelegatedStake.Add(snapshot.DelegatedStake, tcr.DelegatedStake)
+		if tcr.State == StateBonded {
+			snapshot.BondedStake.Add(snapshot.BondedStake, tcr.TotalStake)
+		}
+	}
+	return snapshot, nil
+}
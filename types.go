@@ -17,6 +17,16 @@ const (
 	StateUnregistered
 )
 
+//go:generate stringer -type=TxKind
+type TxKind uint8
+
+const (
+	TxDelegate TxKind = iota
+	TxRegisterTranscoder
+	TxRequestWithdrawal
+	TxCompleteWithdrawals
+)
+
 type Transcoder struct {
 	Address    common.Address
 	State      State
@@ -38,4 +48,28 @@ type WithdrawalInfo struct {
 	// Amount will be non-nil if requested unbonding was withdrawen immediatly.
 	// Which is the case when transcoder is not BONDED or UNBONDING.
 	Amount *big.Int
+	// Tx is a transaction that requested or completed withdrawal.
+	Tx TxResult
+}
+
+// TxResult describes mined staking transaction.
+type TxResult struct {
+	Hash        common.Hash
+	BlockNumber uint64
+	BlockHash   common.Hash
+	GasUsed     uint64
+	// GasPrice is a price that was paid for each unit of used gas.
+	GasPrice *big.Int
+	// Fee is a total fee in wei. GasUsed * GasPrice.
+	Fee *big.Int
+	// Events are all events emitted by the staking contract in the transaction.
+	Events []Event
+}
+
+// FeeEstimate is an expected cost of the staking transaction.
+type FeeEstimate struct {
+	Gas      uint64
+	GasPrice *big.Int
+	// Fee is a maximum fee in wei. Gas * GasPrice.
+	Fee *big.Int
 }